@@ -2,63 +2,297 @@ package clif
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"path/filepath"
 )
 
 // Application is the root definition of a CLI.
 type Application struct {
+	// Name is the name of the binary the application is invoked as. It's
+	// used when generating help output and completion scripts. If left
+	// empty, the base name of os.Args[0] will be used.
+	Name string
+
+	// Description is a short description of the application, used when
+	// generating help output.
+	Description string
+
 	// Commands are the commands that the application supports.
 	Commands []Command
 
 	// Flags are the definitions for any global flags the application
 	// supports.
 	Flags []FlagDef
+
+	// Middleware wraps the HandlerBuilder of every Command, in order,
+	// with the first Middleware being the outermost. It runs outside any
+	// Middleware set on the Commands themselves.
+	Middleware []Middleware
+
+	// PersistentPreRun is run before the HandlerBuilder of every Command,
+	// before the PersistentPreRun hooks of the Commands themselves.
+	PersistentPreRun PreRunHook
+
+	// PersistentPostRun is run after the Handler of every Command, after
+	// the PersistentPostRun hooks of the Commands themselves.
+	PersistentPostRun PostRunHook
+
+	// Config is an optional source of flag values, like a configuration
+	// file. Values from Config are used for flags that aren't included in
+	// the input or set in the environment.
+	Config ConfigSource
+
+	// LookupEnv is used to read the environment variables listed in a
+	// FlagDef's EnvVars, and the COLUMNS environment variable that
+	// overrides the width of the terminal tables are written to. If nil,
+	// os.LookupEnv will be used.
+	LookupEnv func(key string) (string, bool)
+
+	// PrefixMatching lets users abbreviate subcommand and long flag names
+	// to any prefix that only matches one subcommand or flag, so "deploy
+	// --verbose" could be typed as "dep --verb". Ambiguous prefixes
+	// return an AmbiguousPrefixError. Hidden commands can't be
+	// abbreviated.
+	PrefixMatching bool
 }
 
 func (Application) argsAccepted() bool         { return false }
 func (app Application) subcommands() []Command { return app.Commands }
 func (app Application) flags() []FlagDef       { return app.Flags }
+func (Application) argDefs() []ArgDef          { return nil }
+
+// name returns the name of the binary the application is invoked as, falling
+// back on the name of the running binary if no Name is set.
+func (app Application) name() string {
+	if app.Name != "" {
+		return app.Name
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// lookupEnv returns the value of the environment variable named by key, and
+// whether it was set, using LookupEnv if it's set.
+func (app Application) lookupEnv(key string) (string, bool) {
+	if app.LookupEnv != nil {
+		return app.LookupEnv(key)
+	}
+	return os.LookupEnv(key)
+}
 
 // Run executes the invoked command. It routes the input to the appropriate
 // [Command], parses it with the [HandlerBuilder], and executes the [Handler].
-// The return is the status code the command has indicated it exited with.
+// The return is the status code the command has indicated it exited with. If
+// routing fails because of the user's input, the error is presented as a
+// [UsageError] by the RunOptions' UsageError function, which also decides the
+// status code. If routing fails for another reason, like a configuration
+// file that can't be loaded, or the command records an error in the
+// [Response]'s Err, the error is written to the error output and the status
+// code is the one the RunOptions' ExitCode function maps it to.
 func (app Application) Run(ctx context.Context, opts ...RunOption) int {
 	options := RunOptions{
-		Output: os.Stdout,
-		Error:  os.Stderr,
-		Args:   os.Args[1:],
+		Output:     os.Stdout,
+		Error:      os.Stderr,
+		Args:       os.Args[1:],
+		ExitCode:   DefaultExitCode,
+		UsageError: PresentUsageError,
+		exit:       os.Exit,
 	}
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if !options.HandleSignals {
+		return app.run(ctx, options)
+	}
+	ctx, signals := handleSignals(ctx, options.exit)
+	return signals.stop(app.run(ctx, options))
+}
+
+// applicationContextKey is the context key [Application.Run] stores the
+// [Application] being run under, so its FlagDefs are available to [Bind].
+type applicationContextKey struct{}
+
+// run executes the invoked command with the passed RunOptions.
+func (app Application) run(ctx context.Context, options RunOptions) int {
 	resp := &Response{
 		Output: options.Output,
 		Error:  options.Error,
 		Code:   0,
 	}
+	if options.OutputFormats {
+		app = app.withOutputFormats()
+	}
+	if options.TableFlags {
+		app = app.withTableFlags()
+	}
+	if options.Help {
+		app = app.withHelp()
+	}
+
+	// shells ask us what input they can suggest using a hidden
+	// completion request; answer it without routing to a command.
+	if len(options.Args) > 0 && options.Args[0] == completionRequest {
+		for _, suggestion := range complete(ctx, app, options.Args[1:]) {
+			fmt.Fprintln(resp.Output, suggestion) //nolint:errcheck // if there's an error, we can't do anything
+		}
+		return 0
+	}
+
+	// the --help flag can be used anywhere, and doesn't need the rest of
+	// the input to be valid, so check for it before routing.
+	if options.Help {
+		if path, ok := helpRequest(ctx, app, options.Args); ok {
+			fmt.Fprint(resp.Output, CommandHelp(app, path)) //nolint:errcheck // if there's an error, we can't do anything
+			return 0
+		}
+	}
+
 	// Route parses out the distinct parts of our input and finds the right
 	// command to execute them.
 	result, err := Route(ctx, app, options.Args)
+	if err == nil && result.Command.Handler == nil {
+		err = InvalidCommandError(options.Args)
+	}
+	if err != nil && !isUsageMistake(err) {
+		var fallback fallbackError
+		if errors.As(err, &fallback) {
+			err = fallback.err
+		}
+		return options.fail(resp, err)
+	}
+	if err != nil {
+		return options.usageError(resp, UsageError{
+			Application: app,
+			CommandPath: result.CommandPath,
+			Err:         err,
+		})
+	}
+
+	resp.setOutputFormat(result.Flags)
+	resp.setTableOptions(app, result.Flags)
+
+	// make the full result of routing, like parsed positional arguments,
+	// available to the HandlerBuilder and Handler
+	ctx = context.WithValue(ctx, routeResultContextKey{}, result)
+	ctx = context.WithValue(ctx, applicationContextKey{}, app)
+
+	// services provided with Provide are constructed on demand, and
+	// cleaned up once the command is done
+	container := newServices(options.services)
+	defer container.cleanupWithin(options.ShutdownTimeout)
+	ctx = context.WithValue(ctx, servicesContextKey{}, container)
+
+	// persistent pre-run hooks set up anything the command and its
+	// parents share, and can add to the context
+	ctx, err = app.preRun(ctx, result, resp)
 	if err != nil {
-		fmt.Fprintln(resp.Error, err.Error()) //nolint:errcheck // if there's an error, we can't do anything
-		return 1
+		return options.fail(resp, err)
 	}
 
-	if result.Command.Handler == nil {
-		fmt.Fprintln(resp.Error, "invalid command:", strings.Join(options.Args, " ")) //nolint:errcheck // if there's an error, we can't do anything
-		return 1
+	app.handle(ctx, result, resp)
+
+	// persistent post-run hooks clean up after the command, whether it
+	// succeeded or not
+	app.postRun(ctx, result, resp)
+	if resp.Err != nil {
+		return options.fail(resp, resp.Err)
 	}
+	return resp.Code
+}
 
+// handle builds the [Handler] for the routed [Command] and executes it.
+func (app Application) handle(ctx context.Context, result RouteResult, resp *Response) {
 	// Build makes us a handler, parsing all the input and injecting it
 	// into a handler-specific format
-	handler := result.Command.Handler.Build(ctx, result.Flags, result.Args, resp)
-	if resp.Code > 0 {
-		return resp.Code
+	handler := app.middleware(result.CommandPath, result.Command.Handler).Build(ctx, result.Flags, result.Args, resp)
+	if resp.Err != nil || resp.Code > 0 || handler == nil {
+		return
 	}
 
 	// Handle executes the handler
 	handler.Handle(ctx, resp)
-	return resp.Code
+}
+
+// preRun runs the PersistentPreRun hooks of the [Application] and each
+// [Command] in the command path, in that order, returning the context
+// they've built up.
+func (app Application) preRun(ctx context.Context, result RouteResult, resp *Response) (context.Context, error) {
+	hooks := []PreRunHook{app.PersistentPreRun}
+	for _, cmd := range result.CommandPath {
+		hooks = append(hooks, cmd.PersistentPreRun)
+	}
+	for _, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+		var err error
+		ctx, err = hook(ctx, result.Flags, resp)
+		if err != nil {
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
+
+// postRun runs the PersistentPostRun hooks of each [Command] in the command
+// path, deepest first, then the [Application]'s. If any of them return an
+// error and the [Response] doesn't already have one, the first error is
+// recorded in the [Response]'s Err.
+func (app Application) postRun(ctx context.Context, result RouteResult, resp *Response) {
+	hooks := make([]PostRunHook, 0, len(result.CommandPath)+1)
+	for pos := len(result.CommandPath) - 1; pos >= 0; pos-- {
+		hooks = append(hooks, result.CommandPath[pos].PersistentPostRun)
+	}
+	hooks = append(hooks, app.PersistentPostRun)
+	for _, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+		err := hook(ctx, result.Flags, resp)
+		if err != nil && resp.Err == nil {
+			resp.Err = err
+		}
+	}
+}
+
+// middleware wraps the passed [HandlerBuilder] in the [Middleware] set on the
+// [Application] and each [Command] in the passed command path.
+func (app Application) middleware(cmdPath []Command, builder HandlerBuilder) HandlerBuilder { //nolint:ireturn // wrapping an interface
+	chain := append([]Middleware{}, app.Middleware...)
+	for _, cmd := range cmdPath {
+		chain = append(chain, cmd.Middleware...)
+	}
+	for pos := len(chain) - 1; pos >= 0; pos-- {
+		builder = chain[pos](builder)
+	}
+	return builder
+}
+
+// usageError presents the passed [UsageError] to the user and returns the
+// status code it maps to.
+func (options RunOptions) usageError(resp *Response, err UsageError) int {
+	if options.UsageError == nil {
+		return PresentUsageError(resp, err)
+	}
+	return options.UsageError(resp, err)
+}
+
+// isUsageMistake returns true if the passed error from [Route] is about the
+// user's input, and false if it's about the [Application]'s definition or the
+// sources flag values fall back on.
+func isUsageMistake(err error) bool {
+	var fallback fallbackError
+	var duplicate DuplicateFlagNameError
+	return !errors.As(err, &fallback) && !errors.As(err, &duplicate)
+}
+
+// fail writes the passed error to the [Response]'s Error and returns the
+// status code it maps to.
+func (options RunOptions) fail(resp *Response, err error) int {
+	fmt.Fprintln(resp.Error, err.Error()) //nolint:errcheck // if there's an error, we can't do anything
+	if options.ExitCode == nil {
+		return DefaultExitCode(err)
+	}
+	return options.ExitCode(err)
 }
@@ -108,3 +108,29 @@ func ExampleApplication() {
 	// map[baaz:{baaz  true} quux:{quux hello hello}] []
 	// 0
 }
+
+//nolint:errcheck // several places we're not checking errors because they can't fail
+func ExampleRouteResultFromContext() {
+	app := clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "scale",
+				Args: []clif.ArgDef{
+					{Name: "service", Parser: flagtypes.StringParser{}},
+					{Name: "replicas", Parser: flagtypes.IntParser{}},
+				},
+				Handler: funcCommandHandler(func(ctx context.Context, resp *clif.Response) {
+					result, _ := clif.RouteResultFromContext(ctx)
+					service, _ := result.ParsedArgs["service"].(flagtypes.BasicFlag[string])
+					replicas, _ := result.ParsedArgs["replicas"].(flagtypes.BasicFlag[int64])
+					fmt.Fprintf(resp.Output, "scaling %s to %d replicas\n", service.Value, replicas.Value)
+				}),
+			},
+		},
+	}
+	res := app.Run(context.Background(), clif.WithArgs([]string{"scale", "api", "3"}))
+	fmt.Println(res)
+	// output:
+	// scaling api to 3 replicas
+	// 0
+}
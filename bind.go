@@ -0,0 +1,446 @@
+package clif
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bindTagName is the struct tag [Bind] reads.
+const bindTagName = "clif"
+
+// Bind returns a [HandlerBuilder] that builds a Handler of type T, which must
+// be a struct or a pointer to a struct, by filling its exported fields with
+// the Flags and args passed to it. The fields to fill are controlled by the
+// clif struct tag:
+//
+//   - `clif:"flag=name"` fills the field with the value of the flag named
+//     name.
+//   - `clif:"arg=0"` fills the field with the value of the positional
+//     argument at that index. `clif:"arg=name"` does the same for the
+//     argument defined by the [ArgDef] with that Name.
+//   - `clif:"args"` fills a []string field with all the positional
+//     arguments.
+//   - `clif:"-"` leaves the field alone.
+//
+// The other options [ParseFieldTag] understands describe the flag itself,
+// and are used by [Bind] only to look the flag up by its aliases. Flags set
+// using any of the Aliases of their [FlagDef] are found, too.
+//
+// Fields without a clif struct tag are filled with the value of the flag
+// named after the field, lowercased with words separated by dashes, like
+// max-retries for MaxRetries, if that flag is set.
+//
+// Fields can be the [Flag] type, the concrete type of the [Flag], or the type
+// of the [ValueFlag]'s value, like string for a BasicFlag[string] or []int64
+// for a ListFlag[int64]. Numeric values are converted to the field's numeric
+// type if they fit, and pointer fields are allocated when there's a value to
+// fill them with. Positional arguments without a Parser in their [ArgDef] can
+// only fill string fields.
+//
+// If a value can't fill its field, the error is recorded in the [Response]'s
+// Err.
+func Bind[T Handler]() HandlerBuilder { //nolint:ireturn // Bind is meant to be used where a HandlerBuilder is expected
+	return bindBuilder[T]{}
+}
+
+type bindBuilder[T Handler] struct{}
+
+func (bindBuilder[T]) Build(ctx context.Context, flags map[string]Flag, args []string, resp *Response) Handler { //nolint:ireturn // filling an interface
+	handler, err := bind[T](ctx, flags, args)
+	if err != nil {
+		resp.Err = err
+		return nil
+	}
+	return handler
+}
+
+// UnsupportedBindTypeError is returned when [Bind], or something else that
+// reads struct tags, is used with a type that isn't a struct or a pointer to
+// a struct.
+type UnsupportedBindTypeError struct {
+	// Type is the type [Bind] was used with.
+	Type reflect.Type
+}
+
+func (err UnsupportedBindTypeError) Error() string {
+	return fmt.Sprintf("can't bind to %s, only structs and pointers to structs are supported", err.Type)
+}
+
+// InvalidFieldTagError is returned when a field's clif struct tag can't be
+// understood.
+type InvalidFieldTagError struct {
+	// Field is the name of the struct field.
+	Field string
+
+	// Tag is the value of the clif struct tag.
+	Tag string
+}
+
+func (err InvalidFieldTagError) Error() string {
+	return fmt.Sprintf("invalid clif struct tag %q on field %s", err.Tag, err.Field)
+}
+
+// BindTypeMismatchError is returned when [Bind] can't fill a struct field with
+// a value because their types are incompatible.
+type BindTypeMismatchError struct {
+	// Field is the name of the struct field.
+	Field string
+
+	// Source describes where the value came from, like `flag "region"`
+	// or `argument 0`.
+	Source string
+
+	// Expected is the type of the struct field.
+	Expected reflect.Type
+
+	// Got is the type of the value.
+	Got reflect.Type
+}
+
+func (err BindTypeMismatchError) Error() string {
+	return fmt.Sprintf("can't use %s for field %s: expected %s, got %s", err.Source, err.Field, err.Expected, err.Got)
+}
+
+// FieldTag describes the flag or positional argument a struct field holds, as
+// parsed from its clif struct tag by [ParseFieldTag].
+type FieldTag struct {
+	// Skip is set when the field is tagged `clif:"-"` and should be
+	// ignored.
+	Skip bool
+
+	// Flag is the name of the flag the field holds. It's set from
+	// `clif:"flag=name"`, and defaults to the field name, lowercased with
+	// words separated by dashes, for fields that don't hold positional
+	// arguments.
+	Flag string
+
+	// Tagged is set when the field has a clif struct tag.
+	Tagged bool
+
+	// Arg is the index or [ArgDef] Name of the positional argument the
+	// field holds, set from `clif:"arg=0"` or `clif:"arg=name"`.
+	Arg string
+
+	// AllArgs is set when the field is tagged `clif:"args"` and holds all
+	// the positional arguments.
+	AllArgs bool
+
+	// Short is the short name of the flag, set from `clif:"short=r"`.
+	Short rune
+
+	// Aliases are the alternative names of the flag, set from
+	// `clif:"alias=name"`, which can be repeated.
+	Aliases []string
+
+	// EnvVars are the environment variables the flag falls back on, set
+	// from `clif:"env=NAME"`, which can be repeated.
+	EnvVars []string
+
+	// Default is the default value of the flag, set from
+	// `clif:"default=value"`. Default values can't contain commas.
+	Default string
+
+	// Required is set when the field is tagged `clif:"required"`.
+	Required bool
+
+	// Description is the description of the flag, set from the separate
+	// description struct tag, like `description:"The region to use."`.
+	Description string
+}
+
+// ParseFieldTag parses the clif struct tag of the passed field. The tag is a
+// comma-separated list of options:
+//
+//   - `-` skips the field.
+//   - `flag=name` sets the flag name.
+//   - `arg=0` or `arg=name` marks the field as holding a positional
+//     argument.
+//   - `args` marks the field as holding all the positional arguments.
+//   - `short=r` sets the flag's short name.
+//   - `alias=name` adds an alias for the flag.
+//   - `env=NAME` adds an environment variable for the flag to fall back on.
+//   - `default=value` sets the flag's default value.
+//   - `required` marks the flag as required.
+//
+// An [InvalidFieldTagError] is returned if the tag includes an option that
+// isn't on that list, or an option is missing its value.
+func ParseFieldTag(field reflect.StructField) (FieldTag, error) {
+	result := FieldTag{Description: field.Tag.Get("description")}
+	tag, ok := field.Tag.Lookup(bindTagName)
+	if !ok {
+		result.Flag = kebabCase(field.Name)
+		return result, nil
+	}
+	result.Tagged = true
+	invalid := InvalidFieldTagError{Field: field.Name, Tag: tag}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(part), "=")
+		if hasValue && value == "" {
+			return result, invalid
+		}
+		switch key {
+		case "-":
+			result.Skip = true
+		case "flag":
+			result.Flag = value
+		case "arg":
+			if !hasValue {
+				return result, invalid
+			}
+			result.Arg = value
+		case "args":
+			result.AllArgs = true
+		case "short":
+			if utf8.RuneCountInString(value) != 1 {
+				return result, invalid
+			}
+			result.Short, _ = utf8.DecodeRuneInString(value)
+		case "alias":
+			if !hasValue {
+				return result, invalid
+			}
+			result.Aliases = append(result.Aliases, value)
+		case "env":
+			if !hasValue {
+				return result, invalid
+			}
+			result.EnvVars = append(result.EnvVars, value)
+		case "default":
+			result.Default = value
+		case "required":
+			result.Required = true
+		default:
+			return result, invalid
+		}
+	}
+	if result.Flag == "" && !result.Skip && result.Arg == "" && !result.AllArgs {
+		result.Flag = kebabCase(field.Name)
+	}
+	return result, nil
+}
+
+// kebabCase converts a Go identifier like MaxRetries or HTTPPort into a flag
+// name like max-retries or http-port.
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for pos, char := range runes {
+		if unicode.IsUpper(char) && pos > 0 {
+			prevLower := !unicode.IsUpper(runes[pos-1])
+			nextLower := pos+1 < len(runes) && unicode.IsLower(runes[pos+1])
+			if prevLower || nextLower {
+				builder.WriteRune('-')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(char))
+	}
+	return builder.String()
+}
+
+// bind creates a T and fills its fields from the passed Flags and args.
+func bind[T Handler](ctx context.Context, flags map[string]Flag, args []string) (T, error) { //nolint:ireturn // T is the type we're building
+	var zero T
+	typ := reflect.TypeFor[T]()
+	structType := typ
+	if typ.Kind() == reflect.Pointer {
+		structType = typ.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return zero, UnsupportedBindTypeError{Type: typ}
+	}
+	target := reflect.New(structType)
+	result, _ := RouteResultFromContext(ctx)
+	app, _ := ctx.Value(applicationContextKey{}).(Application)
+	input := bindInput{flags: flags, args: args, result: result, appFlags: app.Flags}
+	for pos := range structType.NumField() {
+		field := structType.Field(pos)
+		if !field.IsExported() {
+			continue
+		}
+		tag, err := ParseFieldTag(field)
+		if err != nil {
+			return zero, err
+		}
+		err = input.bindField(target.Elem().Field(pos), field, tag)
+		if err != nil {
+			return zero, err
+		}
+	}
+	if typ.Kind() == reflect.Pointer {
+		return target.Interface().(T), nil //nolint:forcetypeassert // target is always a *T
+	}
+	return target.Elem().Interface().(T), nil //nolint:forcetypeassert // target is always a *T
+}
+
+// bindInput holds the input struct fields are filled from.
+type bindInput struct {
+	flags    map[string]Flag
+	args     []string
+	result   RouteResult
+	appFlags []FlagDef
+}
+
+// bindField fills the passed struct field as its tag describes.
+func (input bindInput) bindField(value reflect.Value, field reflect.StructField, tag FieldTag) error {
+	switch {
+	case tag.Skip:
+		return nil
+	case tag.AllArgs:
+		return assign(value, field.Name, "arguments", input.args)
+	case tag.Arg != "":
+		return input.bindArg(value, field, tag.Arg)
+	case tag.Flag != "":
+		names := append([]string{tag.Flag}, tag.Aliases...)
+		if def, ok := input.flagDef(names); ok {
+			names = append(append(names, def.Name), def.Aliases...)
+		}
+		for _, name := range names {
+			flag, ok := input.flags[strings.ToLower(name)]
+			if ok {
+				return assignFlag(value, field.Name, fmt.Sprintf("flag %q", tag.Flag), flag)
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// flagDef returns the [FlagDef] the routed command accepts that has any of the
+// passed names as its Name or one of its Aliases, so flags set using an alias
+// the struct tag doesn't mention are still bound.
+func (input bindInput) flagDef(names []string) (FlagDef, bool) {
+	defs := append([]FlagDef{}, input.appFlags...)
+	for _, cmd := range input.result.CommandPath {
+		defs = append(defs, cmd.Flags...)
+	}
+	for _, def := range defs {
+		for _, name := range names {
+			if strings.EqualFold(def.Name, name) || slices.ContainsFunc(def.Aliases, func(alias string) bool {
+				return strings.EqualFold(alias, name)
+			}) {
+				return def, true
+			}
+		}
+	}
+	return FlagDef{}, false
+}
+
+// bindArg fills the passed struct field with the positional argument
+// identified by arg, either its index or the Name of its [ArgDef].
+func (input bindInput) bindArg(value reflect.Value, field reflect.StructField, arg string) error {
+	defs := input.result.Command.Args
+	index, err := strconv.Atoi(arg)
+	if err != nil {
+		index = -1
+		for pos, def := range defs {
+			if strings.EqualFold(def.Name, arg) {
+				index = pos
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return InvalidFieldTagError{Field: field.Name, Tag: field.Tag.Get(bindTagName)}
+	}
+	source := "argument " + arg
+	if index < len(defs) {
+		if parsed, ok := input.result.ParsedArgs[defs[index].Name]; ok {
+			return assignFlag(value, field.Name, source, parsed)
+		}
+		if defs[index].Variadic && index < len(input.args) {
+			return assign(value, field.Name, source, input.args[index:])
+		}
+	}
+	if index >= len(input.args) {
+		return nil
+	}
+	return assign(value, field.Name, source, input.args[index])
+}
+
+// assignFlag fills the passed struct field with the passed [Flag], or its
+// value if it's a [ValueFlag].
+func assignFlag(value reflect.Value, field, source string, flag Flag) error {
+	if reflect.TypeOf(flag).AssignableTo(value.Type()) {
+		value.Set(reflect.ValueOf(flag))
+		return nil
+	}
+	valueFlag, ok := flag.(ValueFlag)
+	if !ok {
+		return BindTypeMismatchError{Field: field, Source: source, Expected: value.Type(), Got: reflect.TypeOf(flag)}
+	}
+	return assign(value, field, source, valueFlag.GetValue())
+}
+
+// assign fills the passed struct field with the passed value, converting it
+// if necessary.
+func assign(value reflect.Value, field, source string, input any) error {
+	err := convert(value, reflect.ValueOf(input))
+	if err != nil {
+		return BindTypeMismatchError{Field: field, Source: source, Expected: value.Type(), Got: reflect.TypeOf(input)}
+	}
+	return nil
+}
+
+// errCantConvert is used internally to indicate a value can't be converted
+// to the type of the value it's being assigned to.
+var errCantConvert = errors.New("can't convert")
+
+// convert sets dst to src, converting src to the type of dst if it's a
+// compatible number, a slice of compatible values, or dst is a pointer to a
+// compatible type.
+func convert(dst, src reflect.Value) error {
+	switch {
+	case !src.IsValid():
+		return errCantConvert
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+	case dst.Kind() == reflect.Pointer:
+		ptr := reflect.New(dst.Type().Elem())
+		err := convert(ptr.Elem(), src)
+		if err != nil {
+			return err
+		}
+		dst.Set(ptr)
+		return nil
+	case dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice:
+		results := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for pos := range src.Len() {
+			err := convert(results.Index(pos), src.Index(pos))
+			if err != nil {
+				return err
+			}
+		}
+		dst.Set(results)
+		return nil
+	case dst.CanInt() && src.CanInt():
+		if dst.OverflowInt(src.Int()) {
+			return errCantConvert
+		}
+		dst.SetInt(src.Int())
+		return nil
+	case dst.CanUint() && src.CanUint():
+		if dst.OverflowUint(src.Uint()) {
+			return errCantConvert
+		}
+		dst.SetUint(src.Uint())
+		return nil
+	case dst.CanFloat() && src.CanFloat():
+		if dst.OverflowFloat(src.Float()) {
+			return errCantConvert
+		}
+		dst.SetFloat(src.Float())
+		return nil
+	case dst.Kind() == reflect.String && src.Kind() == reflect.String:
+		dst.SetString(src.String())
+		return nil
+	}
+	return errCantConvert
+}
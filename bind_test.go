@@ -0,0 +1,225 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+type deployHandler struct {
+	Region     string `clif:"flag=region"`
+	MaxRetries int
+	Timeout    *time.Duration
+	Tags       []string
+	Verbose    clif.Flag `clif:"flag=verbose"`
+	Service    string    `clif:"arg=service"`
+	Replicas   int32     `clif:"arg=1"`
+	Args       []string  `clif:"args"`
+	Ignored    string    `clif:"-"`
+}
+
+func (handler deployHandler) Handle(_ context.Context, resp *clif.Response) {
+	var timeout time.Duration
+	if handler.Timeout != nil {
+		timeout = *handler.Timeout
+	}
+	fmt.Fprintf(resp.Output, "region=%q retries=%d timeout=%s tags=%v verbose=%t service=%q replicas=%d args=%v\n", //nolint:errcheck // if there's an error, we can't do anything
+		handler.Region, handler.MaxRetries, timeout, handler.Tags, handler.Verbose != nil, handler.Service, handler.Replicas, handler.Args)
+}
+
+type pointerDeployHandler struct {
+	Region string `clif:"flag=region"`
+}
+
+func (handler *pointerDeployHandler) Handle(_ context.Context, resp *clif.Response) {
+	fmt.Fprintf(resp.Output, "region=%q\n", handler.Region) //nolint:errcheck // if there's an error, we can't do anything
+}
+
+type profileHandler struct {
+	Profile string
+}
+
+func (handler profileHandler) Handle(_ context.Context, resp *clif.Response) {
+	fmt.Fprintf(resp.Output, "profile=%q\n", handler.Profile) //nolint:errcheck // if there's an error, we can't do anything
+}
+
+type mismatchHandler struct {
+	Region int `clif:"flag=region"`
+}
+
+func (mismatchHandler) Handle(_ context.Context, _ *clif.Response) {}
+
+type overflowHandler struct {
+	MaxRetries int8
+}
+
+func (overflowHandler) Handle(_ context.Context, _ *clif.Response) {}
+
+type unknownArgHandler struct {
+	Service string `clif:"arg=svc"`
+}
+
+func (unknownArgHandler) Handle(_ context.Context, _ *clif.Response) {}
+
+type stringHandler string
+
+func (stringHandler) Handle(_ context.Context, _ *clif.Response) {}
+
+func bindTestApp(builder clif.HandlerBuilder) clif.Application {
+	return clif.Application{
+		Flags: []clif.FlagDef{
+			{Name: "profile", Aliases: []string{"p"}, ValueAccepted: true, Parser: flagtypes.StringParser{}},
+		},
+		Commands: []clif.Command{
+			{
+				Name: "deploy",
+				Flags: []clif.FlagDef{
+					{Name: "region", Aliases: []string{"r"}, ValueAccepted: true, Parser: flagtypes.StringParser{}},
+					{Name: "max-retries", ValueAccepted: true, Parser: flagtypes.IntParser{}},
+					{Name: "timeout", ValueAccepted: true, Parser: flagtypes.DurationParser{}},
+					{Name: "tags", ValueAccepted: true, Parser: flagtypes.StringListParser{}},
+					{Name: "verbose", Parser: flagtypes.BoolParser{}},
+				},
+				Args: []clif.ArgDef{
+					{Name: "service", Parser: flagtypes.StringParser{}},
+					{Name: "replicas", Optional: true, Parser: flagtypes.IntParser{}},
+				},
+				Handler: builder,
+			},
+		},
+	}
+}
+
+func TestBind(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		builder        clif.HandlerBuilder
+		input          []string
+		expectedOutput string
+		expectedError  string
+		expectedCode   int
+	}
+
+	cases := map[string]testCase{
+		"all": {
+			builder:        clif.Bind[deployHandler](),
+			input:          []string{"deploy", "--region", "us-east-1", "--max-retries", "3", "--timeout", "30s", "--tags", "web", "--tags", "api", "--verbose", "api", "5"},
+			expectedOutput: `region="us-east-1" retries=3 timeout=30s tags=[web api] verbose=true service="api" replicas=5 args=[api 5]` + "\n",
+		},
+		"unset": {
+			builder:        clif.Bind[deployHandler](),
+			input:          []string{"deploy", "api"},
+			expectedOutput: `region="" retries=0 timeout=0s tags=[] verbose=false service="api" replicas=0 args=[api]` + "\n",
+		},
+		"flag-def-alias": {
+			builder:        clif.Bind[deployHandler](),
+			input:          []string{"deploy", "--r", "us-west-2", "api"},
+			expectedOutput: `region="us-west-2" retries=0 timeout=0s tags=[] verbose=false service="api" replicas=0 args=[api]` + "\n",
+		},
+		"app-flag-def-alias": {
+			builder:        clif.Bind[profileHandler](),
+			input:          []string{"--p", "prod", "deploy", "api"},
+			expectedOutput: `profile="prod"` + "\n",
+		},
+		"pointer": {
+			builder:        clif.Bind[*pointerDeployHandler](),
+			input:          []string{"deploy", "--region", "eu-west-1", "api"},
+			expectedOutput: `region="eu-west-1"` + "\n",
+		},
+		"mismatch": {
+			builder:       clif.Bind[mismatchHandler](),
+			input:         []string{"deploy", "--region", "us-east-1", "api"},
+			expectedError: `can't use flag "region" for field Region: expected int, got string` + "\n",
+			expectedCode:  1,
+		},
+		"overflow": {
+			builder:       clif.Bind[overflowHandler](),
+			input:         []string{"deploy", "--max-retries", "1000", "api"},
+			expectedError: `can't use flag "max-retries" for field MaxRetries: expected int8, got int64` + "\n",
+			expectedCode:  1,
+		},
+		"unknown-arg": {
+			builder:       clif.Bind[unknownArgHandler](),
+			input:         []string{"deploy", "api"},
+			expectedError: `invalid clif struct tag "arg=svc" on field Service` + "\n",
+			expectedCode:  1,
+		},
+		"not-a-struct": {
+			builder:       clif.Bind[stringHandler](),
+			input:         []string{"deploy", "api"},
+			expectedError: "can't bind to clif_test.stringHandler, only structs and pointers to structs are supported\n",
+			expectedCode:  1,
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var output, errOutput bytes.Buffer
+			code := bindTestApp(testCase.builder).Run(context.Background(), clif.WithOutput(&output), clif.WithError(&errOutput), clif.WithArgs(testCase.input))
+			if code != testCase.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedCode, code)
+			}
+			if diff := cmp.Diff(testCase.expectedOutput, output.String()); diff != "" {
+				t.Errorf("Unexpected diff comparing output (-expected, +got): %s", diff)
+			}
+			if diff := cmp.Diff(testCase.expectedError, errOutput.String()); diff != "" {
+				t.Errorf("Unexpected diff comparing error output (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
+
+type scaleHandler struct {
+	Service  string        `clif:"arg=0"`
+	Replicas uint          `clif:"short=n,required" description:"The number of replicas to run."`
+	Region   string        `clif:"alias=zone,env=REGION,default=us-east-1" description:"The region to scale in."`
+	Wait     time.Duration `description:"How long to wait for the replicas to start."`
+	DryRun   bool          `description:"Only print what would change."`
+	Labels   []string      `clif:"flag=label" description:"Labels to apply to the replicas."`
+}
+
+func (handler scaleHandler) Handle(_ context.Context, resp *clif.Response) {
+	fmt.Fprintf(resp.Output, "scaling %s in %s to %d replicas, waiting %s, dry run: %t, labels: %v\n", //nolint:errcheck // if there's an error, we can't do anything
+		handler.Service, handler.Region, handler.Replicas, handler.Wait, handler.DryRun, handler.Labels)
+}
+
+func ExampleBind() {
+	flags, err := flagtypes.StructFlagDefs[scaleHandler]()
+	if err != nil {
+		panic(err)
+	}
+	app := clif.Application{
+		Name: "my-app",
+		Commands: []clif.Command{
+			{
+				Name:    "scale",
+				Flags:   flags,
+				Args:    []clif.ArgDef{{Name: "service"}},
+				Handler: clif.Bind[scaleHandler](),
+			},
+		},
+		LookupEnv: func(string) (string, bool) { return "", false },
+	}
+	fmt.Println(clif.FlagsHelp(app.Commands[0]))
+	res := app.Run(context.Background(), clif.WithArgs([]string{"scale", "-n", "3", "--zone", "eu-west-1", "--wait", "1m", "--label", "web", "--label", "canary", "api"}))
+	fmt.Println(res)
+	res = app.Run(context.Background(), clif.WithArgs([]string{"scale", "--replicas", "2", "--dry-run", "api"}))
+	fmt.Println(res)
+	// output:
+	// replicas	<uint>		The number of replicas to run.
+	// region		<string>	The region to scale in. (default: us-east-1) (env: REGION)
+	// wait		<duration>	How long to wait for the replicas to start.
+	// dry-run		<bool>		Only print what would change.
+	// label		<[]string>	Labels to apply to the replicas.
+	//
+	// scaling api in eu-west-1 to 3 replicas, waiting 1m0s, dry run: false, labels: [web canary]
+	// 0
+	// scaling api in us-east-1 to 2 replicas, waiting 0s, dry run: true, labels: []
+	// 0
+}
@@ -3,7 +3,9 @@ package clif
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
+	"unicode/utf8"
 )
 
 // UnexpectedCommandArgError is returned when a command that wasn't expecting
@@ -33,6 +35,16 @@ type Command struct {
 	// when generating the SubcommandsHelp output.
 	Description string
 
+	// LongDescription is a more detailed description of the command,
+	// which can span multiple paragraphs. It's used in place of
+	// Description when generating the CommandHelp output for this
+	// command.
+	LongDescription string
+
+	// Examples are example invocations of the command, used when
+	// generating the CommandHelp output for this command.
+	Examples []Example
+
 	// Hidden indicates whether a command should be included in
 	// SubcommandsHelp output or not. If set to true, the command will be
 	// omitted from SubcommandsHelp output.
@@ -51,10 +63,32 @@ type Command struct {
 	// used.
 	Handler HandlerBuilder
 
+	// Middleware wraps the HandlerBuilder of this Command and all its
+	// subcommands, in order, with the first Middleware being the
+	// outermost. It runs inside any Middleware set on the Application or
+	// on the parents of this Command.
+	Middleware []Middleware
+
+	// PersistentPreRun is run before the HandlerBuilder of this Command
+	// and all its subcommands, after the PersistentPreRun hooks of the
+	// Application and this Command's parents.
+	PersistentPreRun PreRunHook
+
+	// PersistentPostRun is run after the Handler of this Command and all
+	// its subcommands, before the PersistentPostRun hooks of this
+	// Command's parents and the Application.
+	PersistentPostRun PostRunHook
+
 	// ArgsAccepted indicates whether free input is expected as part of
 	// this command, separate from flag values and subcommands.
 	ArgsAccepted bool
 
+	// Args holds definitions for the positional arguments, if any, that
+	// this command accepts. If set, free input is accepted whether or not
+	// ArgsAccepted is set, and Route will return an ArgCountError if the
+	// number of arguments doesn't fit the definitions.
+	Args []ArgDef
+
 	// AllowNonFlagFlags controls whether things that aren't flags (like
 	// flag values, subcommands, and arguments) can start with --. If
 	// false, we'll throw an error when we encounter an -- that doesn't
@@ -65,9 +99,183 @@ type Command struct {
 	AllowNonFlagFlags bool
 }
 
-func (cmd Command) argsAccepted() bool     { return cmd.ArgsAccepted }
+// Example is an example invocation of a [Command], with an explanation of what
+// it does.
+type Example struct {
+	// Invocation is the full command line, as the user would type it.
+	Invocation string
+
+	// Description explains what the invocation does.
+	Description string
+}
+
+func (cmd Command) argsAccepted() bool     { return cmd.ArgsAccepted || len(cmd.Args) > 0 }
 func (cmd Command) subcommands() []Command { return cmd.Subcommands }
 func (cmd Command) flags() []FlagDef       { return cmd.Flags }
+func (cmd Command) argDefs() []ArgDef      { return cmd.Args }
+
+// ArgDef holds the definition of a positional argument.
+type ArgDef struct {
+	// Name is the name of the argument, used in documentation.
+	Name string
+
+	// Description is a user-friendly description of what the argument is
+	// for, to be presented as part of help output.
+	Description string
+
+	// Optional indicates the argument can be left out. Optional
+	// arguments should come after all required arguments.
+	Optional bool
+
+	// Variadic indicates the argument can be repeated, consuming the
+	// rest of the arguments. Only the last ArgDef of a Command can be
+	// variadic. A variadic argument that isn't Optional must be passed
+	// at least once.
+	Variadic bool
+
+	// Parser determines how the argument should be parsed. It's called
+	// with the Name of the ArgDef and the argument's value, and the
+	// resulting Flag is included in the ParsedArgs of the RouteResult.
+	// Each value of a variadic argument is passed to Parser in turn,
+	// with the result of the previous call as the prior value, so list
+	// parsers can collect all of them. If nil, the argument is only
+	// available in its raw form.
+	Parser FlagParser
+}
+
+// InvalidArgError is returned when a positional argument can't be parsed by
+// the Parser of its [ArgDef].
+type InvalidArgError struct {
+	// Arg is the Name of the ArgDef.
+	Arg string
+
+	// Value is the argument that couldn't be parsed.
+	Value string
+
+	// Err is the error the ArgDef's Parser returned.
+	Err error
+}
+
+func (err InvalidArgError) Error() string {
+	return fmt.Sprintf("invalid value %q for argument %s: %s", err.Value, err.Arg, err.Err)
+}
+
+func (err InvalidArgError) Unwrap() error {
+	return err.Err
+}
+
+// parseArgs parses the passed positional arguments using the Parsers of the
+// passed ArgDefs, returning the results keyed by the ArgDef's Name. Arguments
+// whose ArgDef has no Parser, or that have no ArgDef, are left out.
+func parseArgs(ctx context.Context, defs []ArgDef, args []string) (map[string]Flag, error) {
+	results := map[string]Flag{}
+	for pos, arg := range args {
+		var def ArgDef
+		switch {
+		case pos < len(defs):
+			def = defs[pos]
+		case len(defs) > 0 && defs[len(defs)-1].Variadic:
+			def = defs[len(defs)-1]
+		default:
+			return results, nil
+		}
+		if def.Parser == nil {
+			continue
+		}
+		flag, err := def.Parser.Parse(ctx, def.Name, arg, results[def.Name])
+		if err != nil {
+			return results, InvalidArgError{Arg: def.Name, Value: arg, Err: err}
+		}
+		results[def.Name] = flag
+	}
+	return results, nil
+}
+
+// argCounts returns the minimum and maximum number of arguments the passed
+// ArgDefs accept. If there's no maximum, max will be -1.
+func argCounts(defs []ArgDef) (int, int) {
+	var minArgs int
+	for _, def := range defs {
+		if !def.Optional {
+			minArgs++
+		}
+	}
+	if len(defs) > 0 && defs[len(defs)-1].Variadic {
+		return minArgs, -1
+	}
+	return minArgs, len(defs)
+}
+
+// ArgCountError is returned when a [Command] with Args defined is passed a
+// number of arguments that doesn't fit the definitions.
+type ArgCountError struct {
+	// CommandPath is the Commands, in order, that were matched. Each
+	// Command in the slice is the child of the Command before it in the
+	// slice.
+	CommandPath []Command
+
+	// Min is the minimum number of arguments the Command accepts.
+	Min int
+
+	// Max is the maximum number of arguments the Command accepts, or -1
+	// if there is no maximum.
+	Max int
+
+	// Got is the number of arguments the Command was passed.
+	Got int
+}
+
+func (err ArgCountError) Error() string {
+	var expected string
+	count := err.Min
+	switch {
+	case err.Min == err.Max:
+		expected = fmt.Sprintf("exactly %d", err.Min)
+	case err.Max < 0:
+		expected = fmt.Sprintf("at least %d", err.Min)
+	case err.Min == 0:
+		expected = fmt.Sprintf("at most %d", err.Max)
+		count = err.Max
+	default:
+		expected = fmt.Sprintf("%d to %d", err.Min, err.Max)
+		count = err.Max
+	}
+	noun := "arguments"
+	if count == 1 {
+		noun = "argument"
+	}
+	return fmt.Sprintf("%s expects %s %s, got %d", commandPathName(err.CommandPath), expected, noun, err.Got)
+}
+
+// commandPathName returns the names of the passed Commands, separated by
+// spaces.
+func commandPathName(cmdPath []Command) string {
+	names := make([]string, 0, len(cmdPath))
+	for _, cmd := range cmdPath {
+		names = append(names, cmd.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+// parseOptions controls how input is parsed.
+type parseOptions struct {
+	// allowNonFlagFlags leaves arguments that look like flags but don't
+	// match a FlagDef alone, instead of returning an error.
+	allowNonFlagFlags bool
+
+	// prefixMatching resolves unambiguous prefixes of subcommand and
+	// flag names to the subcommand or flag.
+	prefixMatching bool
+
+	// inherited holds the Persistent FlagDefs of the parents of the
+	// Command being parsed.
+	inherited []FlagDef
+
+	// prior holds the Flags parsed for the parents of the Command being
+	// parsed, so Persistent flags used at more than one level are passed
+	// their prior values.
+	prior map[string]Flag
+}
 
 type parsedCommand struct {
 	subcommand *Command
@@ -76,16 +284,25 @@ type parsedCommand struct {
 	unparsed   []string
 }
 
-func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags bool) (parsedCommand, error) {
+func parse(ctx context.Context, root parseable, args []string, opts parseOptions) (parsedCommand, error) {
 	res := parsedCommand{
 		flags: map[string]Flag{},
 	}
+	maps.Copy(res.flags, opts.prior)
 	if len(args) < 1 {
 		return res, nil
 	}
 	allFlags := map[string]FlagDef{}
-	flagList := listFlagDefs(root, true)
+	shortFlags := map[rune]FlagDef{}
+	flagList := append(listFlagDefs(root, true), opts.inherited...)
 	for _, flag := range flagList {
+		if flag.Short != 0 {
+			_, ok := shortFlags[flag.Short]
+			if ok {
+				return res, DuplicateFlagNameError(string(flag.Short))
+			}
+			shortFlags[flag.Short] = flag
+		}
 		name := strings.ToLower(flag.Name)
 		_, ok := allFlags[name]
 		if ok {
@@ -104,6 +321,87 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 	var openFlagDef *FlagDef
 	var openFlagArg string
 	for pos, arg := range args {
+		// a bare -- marks the end of the flags; everything after it
+		// is an argument to the command, even if it looks like a
+		// flag or matches a subcommand.
+		if arg == "--" {
+			// if there's an open flag definition, it has no
+			// value, close it
+			if openFlagDef != nil {
+				flag, err := openFlagDef.Parser.Parse(ctx, openFlagArg, "", res.flags[openFlagArg])
+				if err != nil {
+					return res, err
+				}
+				res.flags[flag.GetName()] = flag
+			}
+			rest := args[pos+1:]
+			if len(rest) > 0 && !root.argsAccepted() {
+				return res, UnexpectedCommandArgError(rest[0])
+			}
+			res.args = append(res.args, rest...)
+			return res, nil
+		}
+
+		// a single dash followed by a short flag we recognize is one
+		// or more short flags bundled together. Anything else starting
+		// with a single dash is left alone, so it can be used as a
+		// flag value, subcommand, or argument, like a negative number
+		// or - for standard input.
+		if isShortFlagBundle(arg, shortFlags) {
+			// if there's an open flag definition, it has no
+			// value, close it
+			if openFlagDef != nil {
+				flag, err := openFlagDef.Parser.Parse(ctx, openFlagArg, "", res.flags[openFlagArg])
+				if err != nil {
+					return res, err
+				}
+				res.flags[flag.GetName()] = flag
+				openFlagDef = nil
+				openFlagArg = ""
+			}
+			bundle := strings.TrimPrefix(arg, "-")
+			for bundle != "" {
+				short, size := utf8.DecodeRuneInString(bundle)
+				bundle = bundle[size:]
+				flagDef, ok := shortFlags[short]
+				if !ok {
+					return res, UnknownFlagNameError(string(short))
+				}
+				name := strings.ToLower(flagDef.Name)
+
+				// flags that don't accept values can be
+				// followed by more flags in the bundle, but
+				// can't have a value attached
+				if !flagDef.ValueAccepted {
+					if value, hasValue := strings.CutPrefix(bundle, "="); hasValue {
+						return res, UnexpectedFlagValueError{Flag: string(short), Value: value}
+					}
+					flag, err := flagDef.Parser.Parse(ctx, name, "", res.flags[name])
+					if err != nil {
+						return res, err
+					}
+					res.flags[flag.GetName()] = flag
+					continue
+				}
+
+				// flags that accept values use the rest of
+				// the bundle as their value, if there is any
+				if bundle != "" {
+					flag, err := flagDef.Parser.Parse(ctx, name, strings.TrimPrefix(bundle, "="), res.flags[name])
+					if err != nil {
+						return res, err
+					}
+					res.flags[flag.GetName()] = flag
+					break
+				}
+
+				// otherwise, the next arg may be the value
+				openFlagDef = &flagDef
+				openFlagArg = name
+			}
+			continue
+		}
+
 		// if this argument matches a flag definition we're expecting,
 		// let's assume it's that flag definition. In theory it could
 		// be the argument to the open flag definition and just
@@ -115,6 +413,16 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 			argument, value, hasValue := strings.Cut(trimmed, "=")
 			arg = strings.ToLower(argument)
 			flagDef, ok := allFlags[arg]
+			if !ok && opts.prefixMatching {
+				var err error
+				flagDef, ok, err = prefixFlagDef(flagList, arg)
+				if err != nil {
+					return res, err
+				}
+				if ok {
+					arg = strings.ToLower(flagDef.Name)
+				}
+			}
 			if ok {
 				// if we've declared another flag but there's an open
 				// flag definition, it has no value, close it
@@ -159,10 +467,10 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 					openFlagArg = arg
 					continue
 				}
-			} else if !allowNonFlagFlags {
+			} else if !opts.allowNonFlagFlags {
 				// if it doesn't match one of our flag definitions and
 				// we don't allow that, it's an error
-				return res, UnknownFlagNameError(arg)
+				return res, withSuggestions(UnknownFlagNameError(arg), "--"+arg, flagNames(flagList))
 			}
 		}
 
@@ -210,12 +518,30 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 			}
 		}
 
+		// if prefix matching is on, an unambiguous prefix of a
+		// subcommand is that subcommand. Words following a flag
+		// that's waiting on a value are left alone, as they're
+		// more likely to be the value.
+		if opts.prefixMatching && openFlagDef == nil {
+			sub, ok, err := prefixSubcommand(root, lowerArg)
+			if err != nil {
+				return res, err
+			}
+			if ok {
+				res.subcommand = &sub
+				if len(args) > pos+1 {
+					res.unparsed = args[pos+1:]
+				}
+				return res, nil
+			}
+		}
+
 		// this is either an optional value to the open flag definition
 		// (if there is one) or an argument to the command. If we don't
 		// have an open flag definition and don't accept args, this
 		// isn't a valid invocation.
 		if !root.argsAccepted() && openFlagDef == nil {
-			return res, UnexpectedCommandArgError(arg)
+			return res, withSuggestions(UnexpectedCommandArgError(arg), arg, subcommandNames(root))
 		}
 
 		// if we don't accept args and have an open flag definition,
@@ -240,9 +566,10 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 
 		// we have an open flag definition and we accept arguments.
 		// This could be either. Let's assume, if this is the last
-		// argument, that it's a command argument. Otherwise, we're
-		// assuming it's a flag value.
-		if pos == len(args)-1 {
+		// argument and the command still has room for it, that it's
+		// a command argument. Otherwise, we're assuming it's a flag
+		// value.
+		if pos == len(args)-1 && !argsFull(root, len(res.args)) {
 			res.args = append(res.args, arg)
 			continue
 		}
@@ -256,5 +583,33 @@ func parse(ctx context.Context, root parseable, args []string, allowNonFlagFlags
 		openFlagArg = ""
 		continue
 	}
+
+	// if the input ended while a flag was still waiting on its value,
+	// the value is missing.
+	if openFlagDef != nil {
+		return res, MissingFlagValueError(openFlagArg)
+	}
 	return res, nil
 }
+
+// argsFull returns true if the passed [Command] defines its Args and has
+// already been passed as many positional arguments as they accept.
+func argsFull(root parseable, count int) bool {
+	defs := root.argDefs()
+	if len(defs) < 1 {
+		return false
+	}
+	_, maxArgs := argCounts(defs)
+	return maxArgs >= 0 && count >= maxArgs
+}
+
+// isShortFlagBundle returns true if the passed argument starts with a single
+// dash followed by one of the passed short flags.
+func isShortFlagBundle(arg string, shortFlags map[rune]FlagDef) bool {
+	if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+		return false
+	}
+	short, _ := utf8.DecodeRuneInString(strings.TrimPrefix(arg, "-"))
+	_, ok := shortFlags[short]
+	return ok
+}
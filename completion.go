@@ -0,0 +1,397 @@
+package clif
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// completionRequest is the hidden argument shells use to ask the application
+// for completion suggestions. It's followed by the words the user has typed
+// so far, the last of which is the partial word being completed.
+const completionRequest = "__complete"
+
+// Shell identifies a shell that completion scripts can be generated for.
+type Shell string
+
+const (
+	// ShellBash identifies the bash shell.
+	ShellBash Shell = "bash"
+
+	// ShellZsh identifies the zsh shell.
+	ShellZsh Shell = "zsh"
+
+	// ShellFish identifies the fish shell.
+	ShellFish Shell = "fish"
+)
+
+// UnsupportedShellError is returned when a completion script is requested for
+// a [Shell] that completion scripts can't be generated for. The underlying
+// string is the name of the shell.
+type UnsupportedShellError string
+
+func (err UnsupportedShellError) Error() string {
+	return fmt.Sprintf("completion scripts are not supported for shell %q", string(err))
+}
+
+var completionScripts = map[Shell]*template.Template{
+	ShellBash: template.Must(template.New("bash").Parse(`# bash completion for {{ .Name }}
+{{ .Func }}() {
+	local cur cword word
+	local -a words=()
+	# bash splits words on the characters in COMP_WORDBREAKS, so
+	# --flag=value arrives as --flag, =, and value; put them back
+	# together before asking for suggestions.
+	if declare -F _get_comp_words_by_ref >/dev/null; then
+		_get_comp_words_by_ref -n =: cur words cword
+	else
+		for word in "${COMP_WORDS[@]:0:$((COMP_CWORD + 1))}"; do
+			if [[ ${#words[@]} -gt 1 && ( "$word" == "=" || "${words[${#words[@]}-1]}" == *= ) ]]; then
+				words[${#words[@]}-1]+="$word"
+			else
+				words+=("$word")
+			fi
+		done
+		cword=$((${#words[@]} - 1))
+		cur="${words[cword]}"
+	fi
+	local IFS=$'\n'
+	COMPREPLY=($({{ .Name }} ` + completionRequest + ` "${words[@]:1:$cword}" 2>/dev/null))
+	# bash only replaces the part of the word after the last break
+	# character, so trim everything up to it from the suggestions.
+	if [[ "$cur" == *[=:]* ]]; then
+		local prefix="${cur%"${cur##*[=:]}"}"
+		COMPREPLY=("${COMPREPLY[@]#"$prefix"}")
+	fi
+}
+complete -o default -F {{ .Func }} {{ .Name }}
+`)),
+	ShellZsh: template.Must(template.New("zsh").Parse(`#compdef {{ .Name }}
+# zsh completion for {{ .Name }}
+{{ .Func }}() {
+	local -a suggestions
+	suggestions=(${(f)"$({{ .Name }} ` + completionRequest + ` "${(@)words[2,$CURRENT]}" 2>/dev/null)"})
+	compadd -- $suggestions
+}
+compdef {{ .Func }} {{ .Name }}
+`)),
+	ShellFish: template.Must(template.New("fish").Parse(`# fish completion for {{ .Name }}
+function {{ .Func }}
+	set -l tokens (commandline -opc) (commandline -ct)
+	{{ .Name }} ` + completionRequest + ` $tokens[2..-1] 2>/dev/null
+end
+complete -c {{ .Name }} -f -a '({{ .Func }})'
+`)),
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// WriteCompletionScript writes a completion script for the passed [Shell] to
+// the passed [io.Writer]. The script asks the application for suggestions at
+// runtime, so it doesn't need to be regenerated when the application's
+// Commands or FlagDefs change.
+func (app Application) WriteCompletionScript(w io.Writer, shell Shell) error {
+	tmpl, ok := completionScripts[shell]
+	if !ok {
+		return UnsupportedShellError(shell)
+	}
+	name := app.name()
+	return tmpl.Execute(w, map[string]string{
+		"Name": name,
+		"Func": "_" + nonIdentifierChars.ReplaceAllString(name, "_") + "_completion",
+	})
+}
+
+// walkState holds the information we could glean from a partial invocation
+// by walking the command tree.
+type walkState struct {
+	// path is the Commands, in order, that the invocation matched.
+	path []Command
+
+	// node is the deepest Command or Application the invocation matched.
+	node parseable
+
+	// flags holds the Flags that could be parsed from the invocation.
+	flags map[string]Flag
+
+	// args holds the words that weren't flags, flag values, or
+	// subcommands.
+	args []string
+
+	// openFlag is set when the last word was a flag that's still waiting
+	// on its value. openFlagName is the name it was invoked with.
+	openFlag     *FlagDef
+	openFlagName string
+
+	// terminated is set when the invocation included a bare --, after
+	// which everything is an argument.
+	terminated bool
+
+	// inherited holds the Persistent FlagDefs of the parents of node.
+	inherited []FlagDef
+}
+
+// flagDefs returns the FlagDefs that are acceptable at this point in the
+// invocation.
+func (state *walkState) flagDefs() []FlagDef {
+	return append(listFlagDefs(state.node, true), state.inherited...)
+}
+
+// parseFlag parses the passed value for the passed [FlagDef], recording it if
+// it parses successfully. Values that don't parse are ignored, as walking is
+// meant to be forgiving of incomplete input.
+func (state *walkState) parseFlag(ctx context.Context, flag FlagDef, name, value string) {
+	parsed, err := flag.Parser.Parse(ctx, name, value, state.flags[name])
+	if err != nil {
+		return
+	}
+	state.flags[parsed.GetName()] = parsed
+}
+
+// closeOpenFlag parses the open flag, if there is one, as not having a value.
+func (state *walkState) closeOpenFlag(ctx context.Context) {
+	if state.openFlag == nil {
+		return
+	}
+	state.parseFlag(ctx, *state.openFlag, state.openFlagName, "")
+	state.openFlag = nil
+	state.openFlagName = ""
+}
+
+// walk follows words through the command tree the same way [Route] does, but
+// without returning any errors, so it can be used on incomplete or invalid
+// input.
+func walk(ctx context.Context, app Application, words []string) walkState {
+	state := walkState{node: app, flags: map[string]Flag{}}
+	for _, word := range words {
+		if state.terminated {
+			state.args = append(state.args, word)
+			continue
+		}
+		if word == "--" {
+			state.closeOpenFlag(ctx)
+			state.terminated = true
+			continue
+		}
+		sub, ok := findSubcommand(state.node, word)
+		if !ok && app.PrefixMatching && state.openFlag == nil && !strings.HasPrefix(word, "-") {
+			sub, ok, _ = prefixSubcommand(state.node, word)
+		}
+		if ok {
+			state.closeOpenFlag(ctx)
+			state.inherited = append(state.inherited, persistentFlagDefs(state.node.flags())...)
+			state.path = append(state.path, sub)
+			state.node = sub
+			continue
+		}
+		if shortFlags := findShortFlagDefs(state.flagDefs()); isShortFlagBundle(word, shortFlags) {
+			state.closeOpenFlag(ctx)
+			bundle := strings.TrimPrefix(word, "-")
+			for bundle != "" {
+				short, size := utf8.DecodeRuneInString(bundle)
+				bundle = bundle[size:]
+				flag, ok := shortFlags[short]
+				if !ok {
+					break
+				}
+				name := strings.ToLower(flag.Name)
+				if !flag.ValueAccepted {
+					state.parseFlag(ctx, flag, name, "")
+					continue
+				}
+				if bundle != "" {
+					state.parseFlag(ctx, flag, name, strings.TrimPrefix(bundle, "="))
+					break
+				}
+				state.openFlag = &flag
+				state.openFlagName = name
+			}
+			continue
+		}
+		if strings.HasPrefix(word, "--") {
+			name, value, hasValue := strings.Cut(strings.TrimPrefix(word, "--"), "=")
+			name = strings.ToLower(name)
+			flag, ok := findFlagDef(state.flagDefs(), name)
+			if !ok && app.PrefixMatching {
+				flag, ok, _ = prefixFlagDef(state.flagDefs(), name)
+				if ok {
+					name = strings.ToLower(flag.Name)
+				}
+			}
+			if ok {
+				state.closeOpenFlag(ctx)
+				if flag.ValueAccepted && !hasValue {
+					state.openFlag = &flag
+					state.openFlagName = name
+					continue
+				}
+				state.parseFlag(ctx, flag, name, value)
+				continue
+			}
+		}
+		if state.openFlag != nil {
+			state.parseFlag(ctx, *state.openFlag, state.openFlagName, word)
+			state.openFlag = nil
+			state.openFlagName = ""
+			continue
+		}
+		state.args = append(state.args, word)
+	}
+	return state
+}
+
+// findSubcommand returns the subcommand of the passed [Command] or
+// [Application] that name or one of its aliases matches.
+func findSubcommand(command parseable, name string) (Command, bool) {
+	name = strings.ToLower(name)
+	for _, sub := range command.subcommands() {
+		if name == strings.ToLower(sub.Name) {
+			return sub, true
+		}
+		for _, alias := range sub.Aliases {
+			if name == strings.ToLower(alias) {
+				return sub, true
+			}
+		}
+	}
+	return Command{}, false
+}
+
+// findFlagDef returns the [FlagDef] in the passed list that name or one of
+// its aliases matches.
+func findFlagDef(flags []FlagDef, name string) (FlagDef, bool) {
+	name = strings.ToLower(name)
+	for _, flag := range flags {
+		if name == strings.ToLower(flag.Name) {
+			return flag, true
+		}
+		for _, alias := range flag.Aliases {
+			if name == strings.ToLower(alias) {
+				return flag, true
+			}
+		}
+	}
+	return FlagDef{}, false
+}
+
+// findShortFlagDefs returns the FlagDefs in the passed list that have short
+// names, keyed by their short name.
+func findShortFlagDefs(flags []FlagDef) map[rune]FlagDef {
+	results := map[rune]FlagDef{}
+	for _, flag := range flags {
+		if flag.Short != 0 {
+			results[flag.Short] = flag
+		}
+	}
+	return results
+}
+
+// Completer is an optional interface that a [FlagParser] can implement to
+// suggest values for its flag or positional argument, and that the
+// [HandlerBuilder] of a [Command] that accepts arguments can implement to
+// suggest arguments the Parsers of its Args don't.
+type Completer interface {
+	// Complete returns the suggested values for the partial word the
+	// user is typing. The flags and args are the Flags and arguments
+	// that could be parsed from the rest of the invocation so far.
+	// Suggestions that don't start with partial will be discarded.
+	Complete(ctx context.Context, flags map[string]Flag, args []string, partial string) []string
+}
+
+// complete returns the suggestions for the last of the passed words, which
+// is assumed to be incomplete. Hidden commands are never suggested.
+func complete(ctx context.Context, app Application, words []string) []string {
+	var partial string
+	if len(words) > 0 {
+		partial = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	state := walk(ctx, app, words)
+	if state.openFlag != nil {
+		return completeValues(ctx, state, state.openFlag.Parser, "", partial)
+	}
+	if state.terminated {
+		return completeArgs(ctx, state, partial)
+	}
+	if strings.HasPrefix(partial, "--") {
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(partial, "--"), "=")
+		if hasValue {
+			flag, ok := findFlagDef(state.flagDefs(), name)
+			if !ok {
+				return nil
+			}
+			return completeValues(ctx, state, flag.Parser, "--"+name+"=", value)
+		}
+	}
+	var suggestions []string
+	if strings.HasPrefix(partial, "-") {
+		for _, flag := range state.flagDefs() {
+			if strings.HasPrefix("--"+flag.Name, partial) {
+				suggestions = append(suggestions, "--"+flag.Name)
+			}
+		}
+		return suggestions
+	}
+	for _, sub := range state.node.subcommands() {
+		if sub.Hidden {
+			continue
+		}
+		if strings.HasPrefix(sub.Name, partial) {
+			suggestions = append(suggestions, sub.Name)
+		}
+	}
+	return append(suggestions, completeArgs(ctx, state, partial)...)
+}
+
+// completeArgs returns the suggested arguments for partial, if the deepest
+// [Command] matched accepts arguments and can suggest them. The Parser of the
+// [ArgDef] at partial's position is asked first, falling back on the
+// [Command]'s Handler.
+func completeArgs(ctx context.Context, state walkState, partial string) []string {
+	cmd, ok := state.node.(Command)
+	if !ok || !cmd.argsAccepted() {
+		return nil
+	}
+	if def, ok := argDefAt(cmd.Args, len(state.args)); ok {
+		if _, ok := def.Parser.(Completer); ok {
+			return completeValues(ctx, state, def.Parser, "", partial)
+		}
+	}
+	if cmd.Handler == nil {
+		return nil
+	}
+	return completeValues(ctx, state, cmd.Handler, "", partial)
+}
+
+// argDefAt returns the [ArgDef] for the positional argument at the passed
+// index, which is the last one for any index past it if it's Variadic.
+func argDefAt(defs []ArgDef, index int) (ArgDef, bool) {
+	if index < len(defs) {
+		return defs[index], true
+	}
+	if len(defs) > 0 && defs[len(defs)-1].Variadic {
+		return defs[len(defs)-1], true
+	}
+	return ArgDef{}, false
+}
+
+// completeValues asks the passed value, if it implements [Completer], for
+// suggestions for partial, and returns the ones that match with prefix
+// prepended to them.
+func completeValues(ctx context.Context, state walkState, completer any, prefix, partial string) []string {
+	valueCompleter, ok := completer.(Completer)
+	if !ok {
+		return nil
+	}
+	var suggestions []string
+	for _, suggestion := range valueCompleter.Complete(ctx, state.flags, state.args, partial) {
+		if strings.HasPrefix(suggestion, partial) {
+			suggestions = append(suggestions, prefix+suggestion)
+		}
+	}
+	return suggestions
+}
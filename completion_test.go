@@ -0,0 +1,261 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+type regionParser struct {
+	flagtypes.StringParser
+}
+
+func (regionParser) Complete(_ context.Context, _ map[string]clif.Flag, _ []string, _ string) []string {
+	return []string{"us-east-1", "us-west-2", "eu-west-1"}
+}
+
+type serviceCompleter struct {
+	funcCommandHandler
+}
+
+func (serviceCompleter) Complete(_ context.Context, flags map[string]clif.Flag, args []string, _ string) []string {
+	if len(args) > 0 {
+		return nil
+	}
+	if region, ok := flags["region"]; ok {
+		return []string{region.GetRawValue() + "-api", region.GetRawValue() + "-web"}
+	}
+	return []string{"api", "web"}
+}
+
+func completionTestApp() clif.Application {
+	return clif.Application{
+		Name: "my-app",
+		Commands: []clif.Command{
+			{
+				Name:    "deploy",
+				Aliases: []string{"d"},
+				Flags: []clif.FlagDef{
+					{Name: "region", Short: 'r', ValueAccepted: true, Parser: regionParser{}},
+					{Name: "verbose", Short: 'v', Parser: flagtypes.BoolParser{}},
+				},
+				Subcommands: []clif.Command{
+					{Name: "rollback"},
+					{Name: "status"},
+				},
+			},
+			{Name: "describe"},
+			{Name: "debug", Hidden: true},
+			{
+				Name:         "scale",
+				ArgsAccepted: true,
+				Handler:      serviceCompleter{},
+			},
+			{
+				Name: "migrate",
+				Args: []clif.ArgDef{
+					{Name: "from", Parser: regionParser{}},
+					{Name: "to", Variadic: true, Parser: regionParser{}},
+				},
+				Handler: funcCommandHandler(func(_ context.Context, _ *clif.Response) {}),
+			},
+		},
+		Flags: []clif.FlagDef{
+			{Name: "config", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+		},
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input    []string
+		expected []string
+	}
+
+	cases := map[string]testCase{
+		"empty": {
+			input:    []string{""},
+			expected: []string{"deploy", "describe", "scale", "migrate"},
+		},
+		"no-words": {
+			input:    []string{},
+			expected: []string{"deploy", "describe", "scale", "migrate"},
+		},
+		"prefix": {
+			input:    []string{"dep"},
+			expected: []string{"deploy"},
+		},
+		"subcommand": {
+			input:    []string{"deploy", ""},
+			expected: []string{"rollback", "status"},
+		},
+		"alias": {
+			input:    []string{"d", "r"},
+			expected: []string{"rollback"},
+		},
+		"flags": {
+			input:    []string{"deploy", "--"},
+			expected: []string{"--region", "--verbose"},
+		},
+		"global-flags": {
+			input:    []string{"--c"},
+			expected: []string{"--config"},
+		},
+		"after-flag-value": {
+			input:    []string{"--config", "foo", "deploy", "s"},
+			expected: []string{"status"},
+		},
+		"flag-value": {
+			input:    []string{"deploy", "--region", "us"},
+			expected: []string{"us-east-1", "us-west-2"},
+		},
+		"flag-value-equals": {
+			input:    []string{"deploy", "--region=eu"},
+			expected: []string{"--region=eu-west-1"},
+		},
+		"short-flag-value": {
+			input:    []string{"deploy", "-vr", "us-w"},
+			expected: []string{"us-west-2"},
+		},
+		"flag-value-no-completer": {
+			input: []string{"--config", ""},
+		},
+		"args": {
+			input:    []string{"scale", ""},
+			expected: []string{"api", "web"},
+		},
+		"args-with-flags": {
+			input:    []string{"--region", "eu", "scale", "eu-a"},
+			expected: []string{"eu-api"},
+		},
+		"args-after-terminator": {
+			input:    []string{"scale", "--", "w"},
+			expected: []string{"web"},
+		},
+		"args-parser": {
+			input:    []string{"migrate", "us"},
+			expected: []string{"us-east-1", "us-west-2"},
+		},
+		"args-parser-variadic": {
+			input:    []string{"migrate", "us-east-1", "eu-west-1", "us-w"},
+			expected: []string{"us-west-2"},
+		},
+		"args-already-given": {
+			input: []string{"scale", "api", ""},
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var output bytes.Buffer
+			code := completionTestApp().Run(context.Background(), clif.WithOutput(&output), clif.WithArgs(append([]string{"__complete"}, testCase.input...)))
+			if code != 0 {
+				t.Errorf("Expected exit code 0, got %d", code)
+			}
+			got := strings.Fields(output.String())
+			if len(got) == 0 {
+				got = nil
+			}
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("Unexpected diff comparing suggestions (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteCompletionScript(t *testing.T) {
+	t.Parallel()
+	for _, shell := range []clif.Shell{clif.ShellBash, clif.ShellZsh, clif.ShellFish} {
+		t.Run(string(shell), func(t *testing.T) {
+			t.Parallel()
+			var output bytes.Buffer
+			err := completionTestApp().WriteCompletionScript(&output, shell)
+			if err != nil {
+				t.Fatalf("Unexpected error: %+v", err)
+			}
+			if !strings.Contains(output.String(), "my-app __complete") {
+				t.Errorf("Expected script to invoke completion request, got:\n%s", output.String())
+			}
+			if !strings.Contains(output.String(), "_my_app_completion") {
+				t.Errorf("Expected script to use sanitized function name, got:\n%s", output.String())
+			}
+		})
+	}
+
+	err := completionTestApp().WriteCompletionScript(&bytes.Buffer{}, clif.Shell("powershell"))
+	if !errors.Is(err, clif.UnsupportedShellError("powershell")) {
+		t.Errorf("Expected UnsupportedShellError, got %+v", err)
+	}
+}
+
+func TestBashCompletionScriptWordBreaks(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash isn't installed")
+	}
+
+	type testCase struct {
+		words    string
+		cword    int
+		expected string
+	}
+
+	cases := map[string]testCase{
+		"flag-value-equals": {
+			words:    "my-app deploy --region = eu",
+			cword:    4,
+			expected: "eu-west-1\n",
+		},
+		"flag-value-equals-empty": {
+			words:    "my-app deploy --region =",
+			cword:    3,
+			expected: "us-east-1\nus-west-2\neu-west-1\n",
+		},
+		"no-word-breaks": {
+			words:    "my-app deploy --reg",
+			cword:    2,
+			expected: "--region\n",
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var script bytes.Buffer
+			err := completionTestApp().WriteCompletionScript(&script, clif.ShellBash)
+			if err != nil {
+				t.Fatalf("Unexpected error: %+v", err)
+			}
+			// stand in for the application, answering completion
+			// requests the way it would
+			script.WriteString(`my-app() {
+	local IFS=' '
+	case "$*" in
+		"__complete deploy --region=eu") echo "--region=eu-west-1" ;;
+		"__complete deploy --region=") printf '%s\n' --region=us-east-1 --region=us-west-2 --region=eu-west-1 ;;
+		"__complete deploy --reg") echo "--region" ;;
+	esac
+}
+`)
+			script.WriteString("COMP_WORDS=(" + testCase.words + ")\n")
+			script.WriteString("COMP_CWORD=" + strconv.Itoa(testCase.cword) + "\n")
+			script.WriteString("_my_app_completion\n")
+			script.WriteString(`printf '%s\n' "${COMPREPLY[@]}"` + "\n")
+			output, err := exec.Command("bash", "-c", script.String()).CombinedOutput()
+			if err != nil {
+				t.Fatalf("Error running script: %v\n%s", err, output)
+			}
+			if diff := cmp.Diff(testCase.expected, string(output)); diff != "" {
+				t.Errorf("Unexpected diff comparing suggestions (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
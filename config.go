@@ -0,0 +1,348 @@
+package clif
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ConfigSource is an interface for loading flag values from configuration,
+// like a configuration file. Flag values from configuration are only used for
+// flags that aren't included in the input or set in the environment.
+type ConfigSource interface {
+	// LoadConfig returns the configured flag values. It will be called
+	// once each time input is routed.
+	LoadConfig(ctx context.Context) (Config, error)
+}
+
+// Config holds flag values loaded from configuration. Values are grouped into
+// scopes by command path: the empty string is the scope for the whole
+// application, "deploy" is the scope for the deploy command, "deploy
+// rollback" is the scope for the rollback subcommand of the deploy command,
+// and so on. Each scope maps flag names to the values for that flag, which
+// will be passed to the flag's [FlagParser] in order.
+//
+// When looking up a flag value, the scope for the command being run is
+// checked first, followed by the scope for its parent, all the way up to the
+// scope for the whole application. The first scope that has a value for the
+// flag is used.
+type Config map[string]map[string][]string
+
+// LoadConfig fills the [ConfigSource] interface, allowing a Config to be used
+// as a [ConfigSource] directly.
+func (config Config) LoadConfig(_ context.Context) (Config, error) {
+	return config, nil
+}
+
+// lookup returns the configured values for the passed flag name when running
+// the passed command path.
+func (config Config) lookup(cmdPath []Command, name string) ([]string, bool) {
+	name = strings.ToLower(name)
+	for depth := len(cmdPath); depth >= 0; depth-- {
+		scope := make([]string, 0, depth)
+		for _, cmd := range cmdPath[:depth] {
+			scope = append(scope, strings.ToLower(cmd.Name))
+		}
+		values, ok := config[strings.Join(scope, " ")][name]
+		if ok {
+			return values, true
+		}
+	}
+	return nil, false
+}
+
+// set records the passed values for the flag name in the passed scope.
+func (config Config) set(scope []string, name string, values []string) {
+	key := strings.ToLower(strings.Join(scope, " "))
+	if config[key] == nil {
+		config[key] = map[string][]string{}
+	}
+	config[key][strings.ToLower(name)] = values
+}
+
+// ConfigFile is a [ConfigSource] that reads flag values from the file at the
+// path it holds. Files ending in .json are parsed with [ParseJSONConfig], and
+// all other files are parsed with [ParseTOMLConfig]. If the file doesn't
+// exist, no flag values are configured.
+type ConfigFile string
+
+// UserConfigFile returns a [ConfigFile] for the file named config in a
+// directory named for the application in the user's configuration directory,
+// as returned by [os.UserConfigDir]. On Linux, that's usually
+// ~/.config/<name>/config.
+func UserConfigFile(name string) ConfigFile {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return ConfigFile(filepath.Join(dir, name, "config"))
+}
+
+// LoadConfig fills the [ConfigSource] interface and reads the file.
+func (file ConfigFile) LoadConfig(_ context.Context) (Config, error) {
+	if file == "" {
+		return Config{}, nil
+	}
+	contents, err := os.ReadFile(string(file))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(string(file)), ".json") {
+		return ParseJSONConfig(contents)
+	}
+	return ParseTOMLConfig(contents)
+}
+
+// InvalidConfigError is returned when configuration can't be parsed.
+type InvalidConfigError struct {
+	// Line is the line of the configuration the error was found on, if
+	// known.
+	Line int
+
+	// Reason describes what's wrong with the configuration.
+	Reason string
+}
+
+func (err InvalidConfigError) Error() string {
+	if err.Line > 0 {
+		return fmt.Sprintf("invalid configuration on line %d: %s", err.Line, err.Reason)
+	}
+	return "invalid configuration: " + err.Reason
+}
+
+// ParseJSONConfig parses a JSON object into a [Config]. Each key in the object
+// is a flag name, with a string, number, or boolean value, or an array of
+// them. A key with an object value is the scope for the subcommand with that
+// name, and holds flag names and subcommand scopes the same way:
+//
+//	{
+//		"verbose": true,
+//		"deploy": {
+//			"region": "us-east-1",
+//			"tags": ["web", "api"]
+//		}
+//	}
+func ParseJSONConfig(contents []byte) (Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+	var object map[string]any
+	err := decoder.Decode(&object)
+	if err != nil {
+		return nil, InvalidConfigError{Reason: err.Error()}
+	}
+	config := Config{}
+	err = config.setJSON(nil, object)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// setJSON records the values in the passed JSON object in the passed scope,
+// recursing into subcommand scopes.
+func (config Config) setJSON(scope []string, object map[string]any) error {
+	for key, value := range object {
+		if sub, ok := value.(map[string]any); ok {
+			err := config.setJSON(append(append([]string{}, scope...), key), sub)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		list, ok := value.([]any)
+		if !ok {
+			list = []any{value}
+		}
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			switch item := item.(type) {
+			case string:
+				values = append(values, item)
+			case json.Number:
+				values = append(values, item.String())
+			case bool:
+				values = append(values, strconv.FormatBool(item))
+			default:
+				return InvalidConfigError{Reason: fmt.Sprintf("unsupported value for %q: %v", key, item)}
+			}
+		}
+		config.set(scope, key, values)
+	}
+	return nil
+}
+
+// ParseTOMLConfig parses a subset of TOML into a [Config]. Each line is a flag
+// name and value separated by =, and values can be quoted strings, bare words
+// like numbers, booleans, or durations, or arrays of them. Tables set the
+// subcommand scope of the lines that follow them, with dots separating
+// subcommand names. Lines starting with # are comments:
+//
+//	verbose = true
+//
+//	[deploy]
+//	region = "us-east-1"
+//	tags = ["web", "api"]
+//
+//	[deploy.rollback]
+//	timeout = 30s
+func ParseTOMLConfig(contents []byte) (Config, error) {
+	config := Config{}
+	var scope []string
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			table, ok := strings.CutSuffix(text, "]")
+			if !ok {
+				return nil, InvalidConfigError{Line: line, Reason: "unterminated table name"}
+			}
+			scope = nil
+			for _, name := range strings.Split(strings.TrimPrefix(table, "["), ".") {
+				name = strings.TrimSpace(name)
+				if name != "" {
+					scope = append(scope, name)
+				}
+			}
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		if !ok {
+			return nil, InvalidConfigError{Line: line, Reason: "expected key = value"}
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, InvalidConfigError{Line: line, Reason: "missing key"}
+		}
+		values, err := parseTOMLValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, InvalidConfigError{Line: line, Reason: err.Reason}
+		}
+		config.set(scope, key, values)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// parseTOMLValue parses the value half of a line of TOML into the flag values
+// it holds.
+func parseTOMLValue(value string) ([]string, *InvalidConfigError) {
+	items, isArray := strings.CutPrefix(value, "[")
+	if !isArray {
+		item, err := parseTOMLScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		return []string{item}, nil
+	}
+	end := strings.LastIndex(items, "]")
+	if end < 0 {
+		return nil, &InvalidConfigError{Reason: "unterminated array"}
+	}
+	items = items[:end]
+	var values []string
+	for _, item := range splitTOMLArray(items) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parsed, err := parseTOMLScalar(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
+// splitTOMLArray splits the contents of a TOML array on the commas that
+// aren't inside quoted strings.
+func splitTOMLArray(items string) []string {
+	var results []string
+	var quote rune
+	var escaped bool
+	start := 0
+	for pos, char := range items {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && char == '\\':
+			escaped = true
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && char == ',':
+			results = append(results, items[start:pos])
+			start = pos + 1
+		}
+	}
+	return append(results, items[start:])
+}
+
+// parseTOMLScalar parses a single TOML value, removing any quotes and
+// trailing comments.
+func parseTOMLScalar(value string) (string, *InvalidConfigError) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		var escaped bool
+		for pos, char := range value[1:] {
+			switch {
+			case escaped:
+				escaped = false
+			case char == '\\':
+				escaped = true
+			case char == '"':
+				unquoted, err := strconv.Unquote(value[:pos+2])
+				if err != nil {
+					return "", &InvalidConfigError{Reason: "invalid string " + value[:pos+2]}
+				}
+				return unquoted, nil
+			}
+		}
+		return "", &InvalidConfigError{Reason: "unterminated string"}
+	case strings.HasPrefix(value, "'"):
+		literal, _, ok := strings.Cut(value[1:], "'")
+		if !ok {
+			return "", &InvalidConfigError{Reason: "unterminated string"}
+		}
+		return literal, nil
+	}
+	value, _, _ = strings.Cut(value, "#")
+	return strings.TrimSpace(value), nil
+}
+
+// InvalidConfigValueError is returned when a value from configuration can't
+// be parsed as the value of the flag it's set for.
+type InvalidConfigValueError struct {
+	// Flag is the flag name, without leading --.
+	Flag string
+
+	// Err is the error the flag's FlagParser returned.
+	Err error
+}
+
+func (err InvalidConfigValueError) Error() string {
+	return fmt.Sprintf("invalid value for flag %q from configuration: %s", err.Flag, err.Err)
+}
+
+func (err InvalidConfigValueError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,111 @@
+package clif_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+)
+
+func TestParseTOMLConfig(t *testing.T) {
+	t.Parallel()
+	config, err := clif.ParseTOMLConfig([]byte(`# global settings
+Verbose = true
+name = "hello # world" # the name
+
+[deploy]
+region = 'us-east-1'
+tags = ["web", "a, b", api] # all the tags
+
+[ deploy . rollback ]
+timeout = 30s
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	expected := clif.Config{
+		"": {
+			"verbose": {"true"},
+			"name":    {"hello # world"},
+		},
+		"deploy": {
+			"region": {"us-east-1"},
+			"tags":   {"web", "a, b", "api"},
+		},
+		"deploy rollback": {
+			"timeout": {"30s"},
+		},
+	}
+	if diff := cmp.Diff(expected, config); diff != "" {
+		t.Errorf("Unexpected diff comparing config (-expected, +got): %s", diff)
+	}
+}
+
+func TestParseTOMLConfigInvalid(t *testing.T) {
+	t.Parallel()
+	_, err := clif.ParseTOMLConfig([]byte("verbose = true\n\nregion = \"us-east-1\n"))
+	if !errors.Is(err, clif.InvalidConfigError{Line: 3, Reason: "unterminated string"}) {
+		t.Errorf("Expected InvalidConfigError, got %+v", err)
+	}
+}
+
+func TestParseJSONConfig(t *testing.T) {
+	t.Parallel()
+	config, err := clif.ParseJSONConfig([]byte(`{
+		"verbose": true,
+		"timeout": 30,
+		"deploy": {
+			"region": "us-east-1",
+			"tags": ["web", "api"],
+			"rollback": {"force": false}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	expected := clif.Config{
+		"": {
+			"verbose": {"true"},
+			"timeout": {"30"},
+		},
+		"deploy": {
+			"region": {"us-east-1"},
+			"tags":   {"web", "api"},
+		},
+		"deploy rollback": {
+			"force": {"false"},
+		},
+	}
+	if diff := cmp.Diff(expected, config); diff != "" {
+		t.Errorf("Unexpected diff comparing config (-expected, +got): %s", diff)
+	}
+}
+
+func TestConfigFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	err := os.WriteFile(path, []byte(`{"deploy": {"region": "eu-west-1"}}`), 0o600)
+	if err != nil {
+		t.Fatalf("Error writing config file: %+v", err)
+	}
+	config, err := clif.ConfigFile(path).LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	if diff := cmp.Diff(clif.Config{"deploy": {"region": {"eu-west-1"}}}, config); diff != "" {
+		t.Errorf("Unexpected diff comparing config (-expected, +got): %s", diff)
+	}
+
+	config, err = clif.ConfigFile(filepath.Join(dir, "missing")).LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error loading missing file: %+v", err)
+	}
+	if len(config) != 0 {
+		t.Errorf("Expected empty config for missing file, got %+v", config)
+	}
+}
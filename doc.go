@@ -19,4 +19,9 @@
 //
 // Finally, once we have a [Handler], it gets executed, with a [Response] to
 // write output to and record the desired exit code of the command.
+//
+// Applications can generate shell completion scripts for their users with
+// [Application.WriteCompletionScript]. The scripts ask the application for
+// suggestions at runtime, so they always match the application's Commands
+// and FlagDefs.
 package clif
@@ -0,0 +1,138 @@
+// Package docs generates reference documentation, like man pages or Markdown
+// and HTML reference sites, for a [clif.Application] from its Commands and
+// FlagDefs, so the documentation never drifts from the code.
+package docs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"impractical.co/clif"
+)
+
+// ErrMissingName is returned when documentation is requested for a
+// [clif.Application] without a Name. Documentation is usually generated by a
+// separate program, so the name of the running binary can't be used instead.
+var ErrMissingName = errors.New("application must have a name to generate documentation")
+
+// Page is a single page of generated documentation.
+type Page struct {
+	// Filename is the name of the file the page should be written to,
+	// relative to the directory holding the documentation.
+	Filename string
+
+	// CommandPath is the Commands, in order, that lead to the Command the
+	// page documents. It's empty for the page documenting the
+	// Application itself.
+	CommandPath []clif.Command
+
+	// Content is the content of the page.
+	Content []byte
+}
+
+// WritePages writes each of the passed Pages to its Filename in the passed
+// directory, creating the directory if it doesn't exist.
+func WritePages(dir string, pages []Page) error {
+	err := os.MkdirAll(dir, 0o755) //nolint:mnd,gosec // documentation is meant to be readable by everyone
+	if err != nil {
+		return err
+	}
+	for _, page := range pages {
+		err = os.WriteFile(filepath.Join(dir, page.Filename), page.Content, 0o644) //nolint:mnd,gosec // documentation is meant to be readable by everyone
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commandPaths returns the command path of every Command in the passed
+// [clif.Application] that isn't hidden, including the empty command path for
+// the Application itself, parents before their children.
+func commandPaths(app clif.Application) [][]clif.Command {
+	paths := [][]clif.Command{nil}
+	var visit func(parent []clif.Command, cmds []clif.Command)
+	visit = func(parent []clif.Command, cmds []clif.Command) {
+		for _, cmd := range cmds {
+			if cmd.Hidden {
+				continue
+			}
+			path := append(append([]clif.Command{}, parent...), cmd)
+			paths = append(paths, path)
+			visit(path, cmd.Subcommands)
+		}
+	}
+	visit(nil, app.Commands)
+	return paths
+}
+
+// pageName returns the name of the page documenting the passed command path,
+// like "app-foo-bar", without any file extension.
+func pageName(app clif.Application, path []clif.Command) string {
+	name := []string{app.Name}
+	for _, cmd := range path {
+		name = append(name, cmd.Name)
+	}
+	return strings.Join(name, "-")
+}
+
+// pageInfo holds the parts of a [clif.Application] or [clif.Command] that get
+// documented, so both can be documented the same way.
+type pageInfo struct {
+	title       string
+	description string
+	examples    []clif.Example
+	flags       []clif.FlagDef
+	subcommands []clif.Command
+}
+
+// info returns the information to document for the passed command path.
+func info(app clif.Application, path []clif.Command) pageInfo {
+	page := pageInfo{
+		title:       app.Name,
+		description: app.Description,
+		flags:       app.Flags,
+		subcommands: app.Commands,
+	}
+	for _, cmd := range path {
+		page.title += " " + cmd.Name
+		page.description = cmd.Description
+		if cmd.LongDescription != "" {
+			page.description = cmd.LongDescription
+		}
+		page.examples = cmd.Examples
+		page.flags = cmd.Flags
+		page.subcommands = cmd.Subcommands
+	}
+	var visible []clif.Command
+	for _, sub := range page.subcommands {
+		if !sub.Hidden {
+			visible = append(visible, sub)
+		}
+	}
+	page.subcommands = visible
+	return page
+}
+
+// shortDescription returns the one-line description of the passed command
+// path.
+func shortDescription(app clif.Application, path []clif.Command) string {
+	if len(path) < 1 {
+		return app.Description
+	}
+	return path[len(path)-1].Description
+}
+
+// paragraphs splits the passed text into paragraphs on blank lines.
+func paragraphs(text string) []string {
+	var results []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph != "" {
+			results = append(results, paragraph)
+		}
+	}
+	return results
+}
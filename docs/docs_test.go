@@ -0,0 +1,34 @@
+package docs_test
+
+import (
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+func testApp() clif.Application {
+	return clif.Application{
+		Name:        "my-app",
+		Description: "Manages deployments.",
+		Flags: []clif.FlagDef{
+			{Name: "config", Description: "The config file to use.", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+		},
+		Commands: []clif.Command{
+			{
+				Name:            "deploy",
+				Description:     "Deploys a service.",
+				LongDescription: "Deploys a service to a region.\n\n.Deployments can be rolled back.",
+				Examples: []clif.Example{
+					{Invocation: "my-app deploy --region us-east-1", Description: "Deploy to us-east-1."},
+				},
+				Flags: []clif.FlagDef{
+					{Name: "region", Short: 'r', Description: "The region to deploy to.", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+					{Name: "force", Description: "Deploy even if checks fail.", Parser: flagtypes.BoolParser{}},
+				},
+				Subcommands: []clif.Command{
+					{Name: "rollback", Description: "Rolls back a deployment."},
+					{Name: "debug", Hidden: true},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package docs
+
+import (
+	"strings"
+
+	"impractical.co/clif"
+)
+
+// manSection is the manual section the generated man pages belong to; 1 is
+// for user commands.
+const manSection = "1"
+
+var roffEscaper = strings.NewReplacer(`\`, `\e`, `-`, `\-`)
+
+// escapeRoff escapes the passed text so it will be displayed as-is by roff,
+// and not interpreted as formatting instructions.
+func escapeRoff(text string) string {
+	lines := strings.Split(roffEscaper.Replace(text), "\n")
+	for pos, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[pos] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ManPages generates a roff man page for the passed [clif.Application] and
+// for each of its Commands that isn't hidden. Each page has NAME, SYNOPSIS,
+// DESCRIPTION, OPTIONS, and SUBCOMMANDS sections, with sections that would be
+// empty left out. The Application must have a Name.
+func ManPages(app clif.Application) ([]Page, error) {
+	if app.Name == "" {
+		return nil, ErrMissingName
+	}
+	paths := commandPaths(app)
+	pages := make([]Page, 0, len(paths))
+	for _, path := range paths {
+		pages = append(pages, Page{
+			Filename:    pageName(app, path) + "." + manSection,
+			CommandPath: path,
+			Content:     []byte(manPage(app, path)),
+		})
+	}
+	return pages, nil
+}
+
+// manPage returns the roff source for the man page documenting the passed
+// command path.
+func manPage(app clif.Application, path []clif.Command) string {
+	page := info(app, path)
+	name := pageName(app, path)
+
+	var builder strings.Builder
+	builder.WriteString(`.TH "` + strings.ToUpper(escapeRoff(name)) + `" "` + manSection + `" "" "" ""` + "\n")
+	builder.WriteString(".SH NAME\n")
+	builder.WriteString(escapeRoff(name))
+	if description := shortDescription(app, path); description != "" {
+		builder.WriteString(` \- ` + escapeRoff(description))
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString(".SH SYNOPSIS\n")
+	builder.WriteString(escapeRoff(clif.Synopsis(app, path)) + "\n")
+
+	if descriptions := paragraphs(page.description); len(descriptions) > 0 {
+		builder.WriteString(".SH DESCRIPTION\n")
+		for pos, paragraph := range descriptions {
+			if pos > 0 {
+				builder.WriteString(".PP\n")
+			}
+			builder.WriteString(escapeRoff(paragraph) + "\n")
+		}
+	}
+
+	if len(page.flags) > 0 {
+		builder.WriteString(".SH OPTIONS\n")
+		for _, flag := range page.flags {
+			builder.WriteString(".TP\n")
+			if flag.Short != 0 {
+				builder.WriteString(`\fB` + escapeRoff("-"+string(flag.Short)) + `\fR, `)
+			}
+			builder.WriteString(`\fB` + escapeRoff("--"+flag.Name) + `\fR`)
+			if flag.ValueAccepted {
+				builder.WriteString(` \fI<` + escapeRoff(flag.Parser.FlagType()) + `>\fR`)
+			}
+			builder.WriteString("\n" + escapeRoff(flag.Description) + "\n")
+		}
+	}
+
+	if len(page.subcommands) > 0 {
+		builder.WriteString(".SH SUBCOMMANDS\n")
+		for _, sub := range page.subcommands {
+			builder.WriteString(".TP\n")
+			builder.WriteString(`\fB` + escapeRoff(sub.Name) + `\fR` + "\n")
+			builder.WriteString(escapeRoff(sub.Description) + "\n")
+		}
+	}
+
+	if len(page.examples) > 0 {
+		builder.WriteString(".SH EXAMPLES\n")
+		for pos, example := range page.examples {
+			if pos > 0 {
+				builder.WriteString(".PP\n")
+			}
+			if example.Description != "" {
+				builder.WriteString(escapeRoff(example.Description) + "\n")
+			}
+			builder.WriteString(".RS\n.nf\n" + escapeRoff(example.Invocation) + "\n.fi\n.RE\n")
+		}
+	}
+
+	var seeAlso []string
+	if len(path) > 0 {
+		seeAlso = append(seeAlso, pageName(app, path[:len(path)-1]))
+	}
+	for _, sub := range page.subcommands {
+		seeAlso = append(seeAlso, pageName(app, append(append([]clif.Command{}, path...), sub)))
+	}
+	if len(seeAlso) > 0 {
+		builder.WriteString(".SH SEE ALSO\n")
+		for pos, ref := range seeAlso {
+			if pos > 0 {
+				builder.WriteString(",\n")
+			}
+			builder.WriteString(`\fB` + escapeRoff(ref) + `\fR(` + manSection + `)`)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
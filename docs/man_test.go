@@ -0,0 +1,94 @@
+package docs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+	"impractical.co/clif/docs"
+)
+
+func TestManPages(t *testing.T) {
+	t.Parallel()
+	pages, err := docs.ManPages(testApp())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	got := map[string]string{}
+	for _, page := range pages {
+		got[page.Filename] = string(page.Content)
+	}
+	expected := map[string]string{
+		"my-app.1": `.TH "MY\-APP" "1" "" "" ""
+.SH NAME
+my\-app \- Manages deployments.
+.SH SYNOPSIS
+my\-app [\-\-config <string>] <command>
+.SH DESCRIPTION
+Manages deployments.
+.SH OPTIONS
+.TP
+\fB\-\-config\fR \fI<string>\fR
+The config file to use.
+.SH SUBCOMMANDS
+.TP
+\fBdeploy\fR
+Deploys a service.
+.SH SEE ALSO
+\fBmy\-app\-deploy\fR(1)
+`,
+		"my-app-deploy.1": `.TH "MY\-APP\-DEPLOY" "1" "" "" ""
+.SH NAME
+my\-app\-deploy \- Deploys a service.
+.SH SYNOPSIS
+my\-app deploy [\-r|\-\-region <string>] [\-\-force] <command>
+.SH DESCRIPTION
+Deploys a service to a region.
+.PP
+\&.Deployments can be rolled back.
+.SH OPTIONS
+.TP
+\fB\-r\fR, \fB\-\-region\fR \fI<string>\fR
+The region to deploy to.
+.TP
+\fB\-\-force\fR
+Deploy even if checks fail.
+.SH SUBCOMMANDS
+.TP
+\fBrollback\fR
+Rolls back a deployment.
+.SH EXAMPLES
+Deploy to us\-east\-1.
+.RS
+.nf
+my\-app deploy \-\-region us\-east\-1
+.fi
+.RE
+.SH SEE ALSO
+\fBmy\-app\fR(1),
+\fBmy\-app\-deploy\-rollback\fR(1)
+`,
+		"my-app-deploy-rollback.1": `.TH "MY\-APP\-DEPLOY\-ROLLBACK" "1" "" "" ""
+.SH NAME
+my\-app\-deploy\-rollback \- Rolls back a deployment.
+.SH SYNOPSIS
+my\-app deploy rollback
+.SH DESCRIPTION
+Rolls back a deployment.
+.SH SEE ALSO
+\fBmy\-app\-deploy\fR(1)
+`,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected diff comparing man pages (-expected, +got): %s", diff)
+	}
+}
+
+func TestManPagesMissingName(t *testing.T) {
+	t.Parallel()
+	_, err := docs.ManPages(clif.Application{})
+	if !errors.Is(err, docs.ErrMissingName) {
+		t.Errorf("Expected ErrMissingName, got %+v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package docs
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"impractical.co/clif"
+)
+
+// referenceFlag holds the information about a flag shown in a reference
+// page's flag table.
+type referenceFlag struct {
+	Names       []string
+	Type        string
+	Description string
+}
+
+// referenceLink holds the information needed to link to another reference
+// page.
+type referenceLink struct {
+	Name        string
+	Href        string
+	Description string
+}
+
+// referencePage holds the information shown on a reference page, in a form
+// that's easy to render as either Markdown or HTML.
+type referencePage struct {
+	Title       string
+	Synopsis    string
+	Description []string
+	Examples    []clif.Example
+	Flags       []referenceFlag
+	Subcommands []referenceLink
+	Parent      *referenceLink
+}
+
+// reference returns the information to show on the reference page for the
+// passed command path, with links using the passed file extension.
+func reference(app clif.Application, path []clif.Command, ext string) referencePage {
+	page := info(app, path)
+	result := referencePage{
+		Title:       page.title,
+		Synopsis:    clif.Synopsis(app, path),
+		Description: paragraphs(page.description),
+		Examples:    page.examples,
+	}
+	for _, flag := range page.flags {
+		var names []string
+		if flag.Short != 0 {
+			names = append(names, "-"+string(flag.Short))
+		}
+		result.Flags = append(result.Flags, referenceFlag{
+			Names:       append(names, "--"+flag.Name),
+			Type:        flag.Parser.FlagType(),
+			Description: flag.Description,
+		})
+	}
+	for _, sub := range page.subcommands {
+		result.Subcommands = append(result.Subcommands, referenceLink{
+			Name:        sub.Name,
+			Href:        pageName(app, append(append([]clif.Command{}, path...), sub)) + ext,
+			Description: sub.Description,
+		})
+	}
+	if len(path) > 0 {
+		parent := path[:len(path)-1]
+		result.Parent = &referenceLink{
+			Name:        info(app, parent).title,
+			Href:        pageName(app, parent) + ext,
+			Description: shortDescription(app, parent),
+		}
+	}
+	return result
+}
+
+var markdownTableEscaper = strings.NewReplacer(`|`, `\|`, "\n", " ")
+
+// MarkdownPages generates a Markdown reference page for the passed
+// [clif.Application] and for each of its Commands that isn't hidden. Each page
+// has the command's synopsis, description, and examples, a table of its
+// flags, and links to its parent command and subcommands. The Application
+// must have a Name.
+func MarkdownPages(app clif.Application) ([]Page, error) {
+	if app.Name == "" {
+		return nil, ErrMissingName
+	}
+	paths := commandPaths(app)
+	pages := make([]Page, 0, len(paths))
+	for _, path := range paths {
+		pages = append(pages, Page{
+			Filename:    pageName(app, path) + ".md",
+			CommandPath: path,
+			Content:     []byte(markdownPage(reference(app, path, ".md"))),
+		})
+	}
+	return pages, nil
+}
+
+// markdownPage renders the passed reference page as Markdown.
+func markdownPage(page referencePage) string {
+	var builder strings.Builder
+	builder.WriteString("# " + page.Title + "\n\n")
+	builder.WriteString("```\n" + page.Synopsis + "\n```\n")
+	for _, paragraph := range page.Description {
+		builder.WriteString("\n" + paragraph + "\n")
+	}
+
+	if len(page.Examples) > 0 {
+		builder.WriteString("\n## Examples\n")
+		for _, example := range page.Examples {
+			if example.Description != "" {
+				builder.WriteString("\n" + example.Description + "\n")
+			}
+			builder.WriteString("\n```\n" + example.Invocation + "\n```\n")
+		}
+	}
+
+	if len(page.Flags) > 0 {
+		builder.WriteString("\n## Flags\n\n")
+		builder.WriteString("| Flag | Type | Description |\n")
+		builder.WriteString("| --- | --- | --- |\n")
+		for _, flag := range page.Flags {
+			names := make([]string, 0, len(flag.Names))
+			for _, name := range flag.Names {
+				names = append(names, "`"+name+"`")
+			}
+			builder.WriteString("| " + strings.Join(names, ", ") + " | `" + markdownTableEscaper.Replace(flag.Type) + "` | " + markdownTableEscaper.Replace(flag.Description) + " |\n")
+		}
+	}
+
+	if len(page.Subcommands) > 0 {
+		builder.WriteString("\n## Subcommands\n\n")
+		builder.WriteString("| Command | Description |\n")
+		builder.WriteString("| --- | --- |\n")
+		for _, sub := range page.Subcommands {
+			builder.WriteString("| [" + sub.Name + "](" + sub.Href + ") | " + markdownTableEscaper.Replace(sub.Description) + " |\n")
+		}
+	}
+
+	if page.Parent != nil {
+		builder.WriteString("\n## See also\n\n")
+		builder.WriteString("* [" + page.Parent.Name + "](" + page.Parent.Href + ")")
+		if page.Parent.Description != "" {
+			builder.WriteString(": " + page.Parent.Description)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+var htmlPageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<pre><code>{{ .Synopsis }}</code></pre>
+{{- range .Description }}
+<p>{{ . }}</p>
+{{- end }}
+{{- if .Examples }}
+<h2>Examples</h2>
+{{- range .Examples }}
+{{- if .Description }}
+<p>{{ .Description }}</p>
+{{- end }}
+<pre><code>{{ .Invocation }}</code></pre>
+{{- end }}
+{{- end }}
+{{- if .Flags }}
+<h2>Flags</h2>
+<table>
+<thead><tr><th>Flag</th><th>Type</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Flags }}
+<tr><td>{{ range $pos, $name := .Names }}{{ if $pos }}, {{ end }}<code>{{ $name }}</code>{{ end }}</td><td><code>{{ .Type }}</code></td><td>{{ .Description }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- if .Subcommands }}
+<h2>Subcommands</h2>
+<table>
+<thead><tr><th>Command</th><th>Description</th></tr></thead>
+<tbody>
+{{- range .Subcommands }}
+<tr><td><a href="{{ .Href }}">{{ .Name }}</a></td><td>{{ .Description }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+{{- with .Parent }}
+<h2>See also</h2>
+<ul>
+<li><a href="{{ .Href }}">{{ .Name }}</a>{{ if .Description }}: {{ .Description }}{{ end }}</li>
+</ul>
+{{- end }}
+</body>
+</html>
+`))
+
+// HTMLPages generates an HTML reference page for the passed
+// [clif.Application] and for each of its Commands that isn't hidden. The pages
+// contain the same information as the pages generated by [MarkdownPages]. The
+// Application must have a Name.
+func HTMLPages(app clif.Application) ([]Page, error) {
+	if app.Name == "" {
+		return nil, ErrMissingName
+	}
+	paths := commandPaths(app)
+	pages := make([]Page, 0, len(paths))
+	for _, path := range paths {
+		var content bytes.Buffer
+		err := htmlPageTemplate.Execute(&content, reference(app, path, ".html"))
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, Page{
+			Filename:    pageName(app, path) + ".html",
+			CommandPath: path,
+			Content:     content.Bytes(),
+		})
+	}
+	return pages, nil
+}
@@ -0,0 +1,90 @@
+package docs_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif/docs"
+)
+
+func TestMarkdownPages(t *testing.T) {
+	t.Parallel()
+	pages, err := docs.MarkdownPages(testApp())
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	got := map[string]string{}
+	for _, page := range pages {
+		got[page.Filename] = string(page.Content)
+	}
+	expected := map[string]string{
+		"my-app.md": "# my-app\n\n" +
+			"```\nmy-app [--config <string>] <command>\n```\n\n" +
+			"Manages deployments.\n\n" +
+			"## Flags\n\n" +
+			"| Flag | Type | Description |\n" +
+			"| --- | --- | --- |\n" +
+			"| `--config` | `string` | The config file to use. |\n\n" +
+			"## Subcommands\n\n" +
+			"| Command | Description |\n" +
+			"| --- | --- |\n" +
+			"| [deploy](my-app-deploy.md) | Deploys a service. |\n",
+		"my-app-deploy.md": "# my-app deploy\n\n" +
+			"```\nmy-app deploy [-r|--region <string>] [--force] <command>\n```\n\n" +
+			"Deploys a service to a region.\n\n" +
+			".Deployments can be rolled back.\n\n" +
+			"## Examples\n\n" +
+			"Deploy to us-east-1.\n\n" +
+			"```\nmy-app deploy --region us-east-1\n```\n\n" +
+			"## Flags\n\n" +
+			"| Flag | Type | Description |\n" +
+			"| --- | --- | --- |\n" +
+			"| `-r`, `--region` | `string` | The region to deploy to. |\n" +
+			"| `--force` | `bool` | Deploy even if checks fail. |\n\n" +
+			"## Subcommands\n\n" +
+			"| Command | Description |\n" +
+			"| --- | --- |\n" +
+			"| [rollback](my-app-deploy-rollback.md) | Rolls back a deployment. |\n\n" +
+			"## See also\n\n" +
+			"* [my-app](my-app.md): Manages deployments.\n",
+		"my-app-deploy-rollback.md": "# my-app deploy rollback\n\n" +
+			"```\nmy-app deploy rollback\n```\n\n" +
+			"Rolls back a deployment.\n\n" +
+			"## See also\n\n" +
+			"* [my-app deploy](my-app-deploy.md): Deploys a service.\n",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("Unexpected diff comparing Markdown pages (-expected, +got): %s", diff)
+	}
+}
+
+func TestHTMLPages(t *testing.T) {
+	t.Parallel()
+	app := testApp()
+	app.Commands[0].Description = "Deploys a <service>."
+	pages, err := docs.HTMLPages(app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %+v", err)
+	}
+	var filenames []string
+	for _, page := range pages {
+		filenames = append(filenames, page.Filename)
+	}
+	if diff := cmp.Diff([]string{"my-app.html", "my-app-deploy.html", "my-app-deploy-rollback.html"}, filenames); diff != "" {
+		t.Errorf("Unexpected diff comparing filenames (-expected, +got): %s", diff)
+	}
+	for _, expected := range []string{
+		`<h1>my-app deploy</h1>`,
+		`<tr><td><code>-r</code>, <code>--region</code></td><td><code>string</code></td><td>The region to deploy to.</td></tr>`,
+		`<tr><td><a href="my-app-deploy-rollback.html">rollback</a></td><td>Rolls back a deployment.</td></tr>`,
+		`<li><a href="my-app.html">my-app</a>: Manages deployments.</li>`,
+	} {
+		if !strings.Contains(string(pages[1].Content), expected) {
+			t.Errorf("Expected page to contain %q, got:\n%s", expected, pages[1].Content)
+		}
+	}
+	if !strings.Contains(string(pages[0].Content), `Deploys a &lt;service&gt;.`) {
+		t.Errorf("Expected description to be escaped, got:\n%s", pages[0].Content)
+	}
+}
@@ -0,0 +1,65 @@
+package clif
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ExitOK is the status code for a command that succeeded.
+	ExitOK = 0
+
+	// ExitFailure is the status code for a command that failed.
+	ExitFailure = 1
+
+	// ExitUsage is the status code for a command that was invoked
+	// incorrectly, like with an unknown flag or the wrong number of
+	// arguments.
+	ExitUsage = 2
+)
+
+// ExitCoder is an interface errors can implement to control the status code
+// the command exits with when [DefaultExitCode] is used.
+type ExitCoder interface {
+	// ExitCode returns the status code the command should exit with.
+	ExitCode() int
+}
+
+// ExitError wraps an error with the status code the command should exit with.
+type ExitError struct {
+	// Code is the status code the command should exit with.
+	Code int
+
+	// Err is the error the command failed with.
+	Err error
+}
+
+func (err ExitError) Error() string {
+	if err.Err == nil {
+		return fmt.Sprintf("exit status %d", err.Code)
+	}
+	return err.Err.Error()
+}
+
+func (err ExitError) Unwrap() error {
+	return err.Err
+}
+
+// ExitCode fills the [ExitCoder] interface.
+func (err ExitError) ExitCode() int {
+	return err.Code
+}
+
+// DefaultExitCode is the default function for mapping errors to status codes.
+// It returns [ExitOK] for nil errors, the ExitCode of the first error in the
+// chain that implements [ExitCoder], and [ExitFailure] for everything else.
+func DefaultExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var coder ExitCoder
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return ExitFailure
+}
@@ -0,0 +1,112 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+)
+
+var errNotFound = errors.New("service not found")
+
+type funcErrorHandler func(ctx context.Context, resp *clif.Response) error
+
+func (f funcErrorHandler) Build(_ context.Context, _ map[string]clif.Flag, _ []string, _ *clif.Response) clif.Handler { //nolint:ireturn // filling an interface
+	return clif.HandleErrors(f)
+}
+
+func (f funcErrorHandler) Handle(ctx context.Context, resp *clif.Response) error {
+	return f(ctx, resp)
+}
+
+type failingBuilder struct {
+	err error
+}
+
+func (builder failingBuilder) Build(_ context.Context, _ map[string]clif.Flag, _ []string, resp *clif.Response) clif.Handler { //nolint:ireturn // filling an interface
+	resp.Err = builder.err
+	return nil
+}
+
+func TestRunExitCodes(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		handler       clif.HandlerBuilder
+		opts          []clif.RunOption
+		expectedCode  int
+		expectedError string
+	}
+
+	cases := map[string]testCase{
+		"success": {
+			handler: funcErrorHandler(func(_ context.Context, _ *clif.Response) error {
+				return nil
+			}),
+			expectedCode: clif.ExitOK,
+		},
+		"error": {
+			handler: funcErrorHandler(func(_ context.Context, _ *clif.Response) error {
+				return errNotFound
+			}),
+			expectedCode:  clif.ExitFailure,
+			expectedError: "service not found\n",
+		},
+		"exit-error": {
+			handler: funcErrorHandler(func(_ context.Context, _ *clif.Response) error {
+				return clif.ExitError{Code: 3, Err: errNotFound}
+			}),
+			expectedCode:  3,
+			expectedError: "service not found\n",
+		},
+		"wrapped-exit-error": {
+			handler: funcErrorHandler(func(_ context.Context, _ *clif.Response) error {
+				return fmt.Errorf("deploying: %w", clif.ExitError{Code: 3, Err: errNotFound})
+			}),
+			expectedCode:  3,
+			expectedError: "deploying: service not found\n",
+		},
+		"custom-mapper": {
+			handler: funcErrorHandler(func(_ context.Context, _ *clif.Response) error {
+				return fmt.Errorf("deploying: %w", errNotFound)
+			}),
+			opts: []clif.RunOption{clif.WithExitCodes(func(err error) int {
+				if errors.Is(err, errNotFound) {
+					return 3
+				}
+				return clif.DefaultExitCode(err)
+			})},
+			expectedCode:  3,
+			expectedError: "deploying: service not found\n",
+		},
+		"build-error": {
+			handler:       failingBuilder{err: clif.ExitError{Code: clif.ExitUsage, Err: errNotFound}},
+			expectedCode:  clif.ExitUsage,
+			expectedError: "service not found\n",
+		},
+		"build-nil-handler": {
+			handler:      failingBuilder{},
+			expectedCode: clif.ExitOK,
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var errOutput bytes.Buffer
+			app := clif.Application{
+				Commands: []clif.Command{{Name: "deploy", Handler: testCase.handler}},
+			}
+			opts := append([]clif.RunOption{clif.WithError(&errOutput), clif.WithArgs([]string{"deploy"})}, testCase.opts...)
+			code := app.Run(context.Background(), opts...)
+			if code != testCase.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedCode, code)
+			}
+			if diff := cmp.Diff(testCase.expectedError, errOutput.String()); diff != "" {
+				t.Errorf("Unexpected diff comparing error output (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package clif
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
 // FlagDef holds the definition of a flag.
@@ -19,6 +20,15 @@ type FlagDef struct {
 	// or the parser won't know which command to apply the flag to.
 	Aliases []string
 
+	// Short is an optional single character the flag can be invoked with,
+	// using a single dash instead of two: -v instead of --verbose. Short
+	// flags that don't accept values can be bundled together, like -xvf,
+	// and short flags that accept values can have their value attached,
+	// like -n5, or passed as the next argument, like -n 5. Unlike Name
+	// and Aliases, Short is case sensitive. Short flags must be unique
+	// across all commands, just like Name and Aliases.
+	Short rune
+
 	// Description is a user-friendly description of what the flag does and
 	// what it's for, to be presented as part of help output.
 	Description string
@@ -34,10 +44,58 @@ type FlagDef struct {
 	// before the subcommand it belongs to will return an error.
 	OnlyAfterCommandName bool
 
+	// Persistent indicates whether the flag should also be accepted after
+	// the names of the subcommands of the Application or Command it's
+	// defined on, all the way down the tree. Without it, flags defined on
+	// an Application or Command are only accepted before the name of the
+	// subcommand being run. Subcommands can't define flags with the same
+	// names as the persistent flags of their parents.
+	Persistent bool
+
+	// EnvVars holds the names of environment variables to use as the
+	// flag's value when the flag isn't included in the input. The first
+	// one that is set will be used. Only flags defined on the Application
+	// or on the Commands leading to the Command being run are read from
+	// the environment.
+	EnvVars []string
+
+	// Required indicates the flag must have a value, either from the
+	// input or from one of its fallbacks, like EnvVars, configuration, or
+	// Default. Only flags defined on the Application or on the Commands
+	// leading to the Command being run are required.
+	Required bool
+
+	// Default is the value to use for the flag when it isn't included in
+	// the input, set in the environment, or set in configuration. It
+	// will be parsed by Parser, just like a value the user passed. If
+	// empty, flags that aren't set will be left out of the Flags passed
+	// to the HandlerBuilder.
+	Default string
+
 	// Parser determines how the flag value should be parsed.
 	Parser FlagParser
 }
 
+// FlagSource describes where the value of a [Flag] came from.
+type FlagSource string
+
+const (
+	// FlagSourceArgs indicates the [Flag] was included in the input.
+	FlagSourceArgs FlagSource = "args"
+
+	// FlagSourceEnv indicates the [Flag] was read from one of the
+	// environment variables in its [FlagDef]'s EnvVars.
+	FlagSourceEnv FlagSource = "env"
+
+	// FlagSourceConfig indicates the [Flag] was read from the
+	// [Application]'s Config.
+	FlagSourceConfig FlagSource = "config"
+
+	// FlagSourceDefault indicates the [Flag] was parsed from its
+	// [FlagDef]'s Default.
+	FlagSourceDefault FlagSource = "default"
+)
+
 // FlagParser is an interface for parsing flag values. Implementing it allows
 // the definition of new types of flags.
 type FlagParser interface {
@@ -60,6 +118,118 @@ type Flag interface {
 	GetRawValue() string
 }
 
+// isSet returns true if the passed Flags include a value for the flag, under
+// its name or any of its aliases.
+func (def FlagDef) isSet(flags map[string]Flag) bool {
+	if _, ok := flags[strings.ToLower(def.Name)]; ok {
+		return true
+	}
+	for _, alias := range def.Aliases {
+		if _, ok := flags[strings.ToLower(alias)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValueFlag is an optional interface that a [Flag] can implement to expose its
+// parsed value without callers needing to know its type. [Bind] uses it to
+// fill struct fields with the values of Flags.
+type ValueFlag interface {
+	Flag
+
+	// GetValue returns the parsed value of the flag.
+	GetValue() any
+}
+
+// persistentFlagDefs returns the FlagDefs in the passed list that are
+// Persistent.
+func persistentFlagDefs(flags []FlagDef) []FlagDef {
+	var results []FlagDef
+	for _, flag := range flags {
+		if flag.Persistent {
+			results = append(results, flag)
+		}
+	}
+	return results
+}
+
+// inheritedFlagDefs returns the Persistent FlagDefs defined on the passed
+// [Application] and the parents of the last [Command] in the passed command
+// path, which are accepted by that [Command].
+func inheritedFlagDefs(app Application, cmdPath []Command) []FlagDef {
+	if len(cmdPath) < 1 {
+		return nil
+	}
+	flags := persistentFlagDefs(app.Flags)
+	for _, cmd := range cmdPath[:len(cmdPath)-1] {
+		flags = append(flags, persistentFlagDefs(cmd.Flags)...)
+	}
+	return flags
+}
+
+// withBuiltinFlag returns a copy of the [Application] with the passed
+// Persistent [FlagDef] added to it, for flags the library provides, like the
+// output flag. Commands that define a flag with the same name, and their
+// subcommands, are left without it, and the built-in flag is added below the
+// Application to the Commands that don't conflict instead. If a flag the
+// built-in flag would be added alongside already uses its Short name, it's
+// added without one.
+func (app Application) withBuiltinFlag(flag FlagDef) Application {
+	app.Flags, app.Commands = addBuiltinFlag(app.Flags, app.Commands, flag)
+	return app
+}
+
+// addBuiltinFlag adds the passed [FlagDef] to the passed FlagDefs if neither
+// they nor any of the FlagDefs of the passed subcommands and their
+// descendants conflict with it. Otherwise, it's added to each of the
+// subcommands that don't conflict with it, only accepted after their names so
+// it doesn't conflict with the flags of their siblings. The returned values
+// are copies, the passed slices are left unmodified.
+func addBuiltinFlag(defs []FlagDef, subcommands []Command, flag FlagDef) ([]FlagDef, []Command) {
+	nameTaken, shortTaken := flagConflicts(append(append([]FlagDef{}, defs...), descendantFlagDefs(subcommands)...), flag)
+	if !nameTaken && !shortTaken {
+		return append(append([]FlagDef{}, defs...), flag), subcommands
+	}
+	ownName, ownShort := flagConflicts(defs, flag)
+	if ownName {
+		return defs, subcommands
+	}
+	if ownShort {
+		flag.Short = 0
+		return addBuiltinFlag(defs, subcommands, flag)
+	}
+	flag.OnlyAfterCommandName = true
+	results := make([]Command, 0, len(subcommands))
+	for _, sub := range subcommands {
+		sub.Flags, sub.Subcommands = addBuiltinFlag(sub.Flags, sub.Subcommands, flag)
+		results = append(results, sub)
+	}
+	return defs, results
+}
+
+// flagConflicts returns whether any of the passed FlagDefs use the name or
+// the Short name of the passed [FlagDef].
+func flagConflicts(defs []FlagDef, flag FlagDef) (bool, bool) {
+	_, name := findFlagDef(defs, flag.Name)
+	var short bool
+	if flag.Short != 0 {
+		_, short = findShortFlagDefs(defs)[flag.Short]
+	}
+	return name, short
+}
+
+// descendantFlagDefs returns the FlagDefs of the passed Commands and all their
+// subcommands, including the ones only accepted after the Command's name.
+func descendantFlagDefs(cmds []Command) []FlagDef {
+	var results []FlagDef
+	for _, cmd := range cmds {
+		results = append(results, cmd.Flags...)
+		results = append(results, descendantFlagDefs(cmd.Subcommands)...)
+	}
+	return results
+}
+
 // listFlagDefs recursively returns the list of [FlagDef]s defined on the
 // passed [parseable] and all its subcommands.
 func listFlagDefs(command parseable, activeCommand bool) []FlagDef {
@@ -115,3 +285,61 @@ type MissingFlagValueError string
 func (err MissingFlagValueError) Error() string {
 	return fmt.Sprintf("no value set for flag that requires value %q", string(err))
 }
+
+// InvalidEnvVarError is returned when the value of an environment variable
+// can't be parsed as the value of the flag it's set for.
+type InvalidEnvVarError struct {
+	// Flag is the flag name, without leading --.
+	Flag string
+
+	// EnvVar is the name of the environment variable.
+	EnvVar string
+
+	// Err is the error the flag's FlagParser returned.
+	Err error
+}
+
+func (err InvalidEnvVarError) Error() string {
+	return fmt.Sprintf("invalid value for flag %q from environment variable %s: %s", err.Flag, err.EnvVar, err.Err)
+}
+
+func (err InvalidEnvVarError) Unwrap() error {
+	return err.Err
+}
+
+// InvalidDefaultError is returned when a [FlagDef]'s Default can't be parsed by
+// its Parser.
+type InvalidDefaultError struct {
+	// Flag is the flag name, without leading --.
+	Flag string
+
+	// Err is the error the flag's FlagParser returned.
+	Err error
+}
+
+func (err InvalidDefaultError) Error() string {
+	return fmt.Sprintf("invalid default value for flag %q: %s", err.Flag, err.Err)
+}
+
+func (err InvalidDefaultError) Unwrap() error {
+	return err.Err
+}
+
+// MissingRequiredFlagError is returned when a [FlagDef] with Required set
+// doesn't have a value.
+type MissingRequiredFlagError struct {
+	// CommandPath is the Commands, in order, that were matched. Each
+	// Command in the slice is the child of the Command before it in the
+	// slice.
+	CommandPath []Command
+
+	// Flag is the flag name, without leading --.
+	Flag string
+}
+
+func (err MissingRequiredFlagError) Error() string {
+	if len(err.CommandPath) < 1 {
+		return fmt.Sprintf("missing required flag --%s", err.Flag)
+	}
+	return fmt.Sprintf("missing required flag --%s for %s", err.Flag, commandPathName(err.CommandPath))
+}
@@ -40,3 +40,9 @@ func (flag BasicFlag[FlagType]) GetName() string {
 func (flag BasicFlag[FlagType]) GetRawValue() string {
 	return flag.RawValue
 }
+
+// GetValue fills the [clif.ValueFlag] interface and returns the parsed value of
+// the flag.
+func (flag BasicFlag[FlagType]) GetValue() any {
+	return flag.Value
+}
@@ -24,3 +24,9 @@ func (flag ListFlag[FlagType]) GetName() string {
 func (flag ListFlag[FlagType]) GetRawValue() string {
 	return flag.RawValue
 }
+
+// GetValue fills the [clif.ValueFlag] interface and returns the parsed value of
+// the flag.
+func (flag ListFlag[FlagType]) GetValue() any {
+	return flag.Value
+}
@@ -0,0 +1,154 @@
+package flagtypes
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"impractical.co/clif"
+)
+
+// UnsupportedFieldTypeError is returned when [StructFlagDefs] is asked to
+// create a [clif.FlagDef] for a struct field whose type there's no
+// [clif.FlagParser] for.
+type UnsupportedFieldTypeError struct {
+	// Field is the name of the struct field.
+	Field string
+
+	// Type is the type of the struct field.
+	Type reflect.Type
+}
+
+func (err UnsupportedFieldTypeError) Error() string {
+	return fmt.Sprintf("no flag parser for field %s of type %s", err.Field, err.Type)
+}
+
+// StructFlagDefs returns the FlagDefs for the exported fields of T, which must
+// be a struct or a pointer to a struct, in the order the fields are declared.
+// It's meant to be used with the same struct as [clif.Bind], so a flag only
+// needs to be declared once.
+//
+// Each field's clif struct tag is parsed with [clif.ParseFieldTag], which
+// controls the flag's name, short name, aliases, environment variables,
+// default, and whether it's required. The flag's description comes from the
+// field's description struct tag. Fields tagged to hold positional arguments
+// or to be skipped don't get a FlagDef.
+//
+// The field's type determines the flag's [clif.FlagParser]:
+//
+//   - bool fields use [BoolParser], and don't accept values.
+//   - string fields use [StringParser].
+//   - [time.Duration] fields use [DurationParser].
+//   - [time.Time] fields use [TimeParser].
+//   - int fields of any size use [IntParser].
+//   - uint fields of any size use [UintParser].
+//   - float fields of any size use [FloatParser].
+//   - slices of any of those use the matching list parser, like
+//     [StringListParser] for []string.
+//
+// Pointers to any of those types are treated like the type they point to.
+// Fields without a clif struct tag are skipped if their type isn't on that
+// list; fields with one return an [UnsupportedFieldTypeError].
+func StructFlagDefs[T any]() ([]clif.FlagDef, error) {
+	typ := reflect.TypeFor[T]()
+	structType := typ
+	if typ.Kind() == reflect.Pointer {
+		structType = typ.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, clif.UnsupportedBindTypeError{Type: typ}
+	}
+	var defs []clif.FlagDef
+	for pos := range structType.NumField() {
+		field := structType.Field(pos)
+		if !field.IsExported() {
+			continue
+		}
+		tag, err := clif.ParseFieldTag(field)
+		if err != nil {
+			return nil, err
+		}
+		if tag.Skip || tag.Arg != "" || tag.AllArgs {
+			continue
+		}
+		parser, valueAccepted, ok := parserFor(field.Type)
+		if !ok {
+			if tag.Tagged {
+				return nil, UnsupportedFieldTypeError{Field: field.Name, Type: field.Type}
+			}
+			continue
+		}
+		defs = append(defs, clif.FlagDef{
+			Name:          tag.Flag,
+			Aliases:       tag.Aliases,
+			Short:         tag.Short,
+			Description:   tag.Description,
+			ValueAccepted: valueAccepted,
+			EnvVars:       tag.EnvVars,
+			Required:      tag.Required,
+			Default:       tag.Default,
+			Parser:        parser,
+		})
+	}
+	return defs, nil
+}
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// parserFor returns the [clif.FlagParser] for a struct field of the passed
+// type, and whether the flag should accept a value.
+func parserFor(typ reflect.Type) (clif.FlagParser, bool, bool) { //nolint:ireturn // returning one of many FlagParsers
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Slice {
+		parser, ok := listParserFor(typ.Elem())
+		return parser, true, ok
+	}
+	switch {
+	case typ == durationType:
+		return DurationParser{}, true, true
+	case typ == timeType:
+		return TimeParser{}, true, true
+	}
+	switch typ.Kind() { //nolint:exhaustive // everything else is unsupported
+	case reflect.Bool:
+		return BoolParser{}, false, true
+	case reflect.String:
+		return StringParser{}, true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return IntParser{}, true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return UintParser{}, true, true
+	case reflect.Float32, reflect.Float64:
+		return FloatParser{}, true, true
+	}
+	return nil, false, false
+}
+
+// listParserFor returns the list [clif.FlagParser] for a slice field whose
+// elements are of the passed type.
+func listParserFor(typ reflect.Type) (clif.FlagParser, bool) { //nolint:ireturn // returning one of many FlagParsers
+	switch {
+	case typ == durationType:
+		return DurationListParser{}, true
+	case typ == timeType:
+		return TimeListParser{}, true
+	}
+	switch typ.Kind() { //nolint:exhaustive // everything else is unsupported
+	case reflect.Bool:
+		return BoolListParser{}, true
+	case reflect.String:
+		return StringListParser{}, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return IntListParser{}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return UintListParser{}, true
+	case reflect.Float32, reflect.Float64:
+		return FloatListParser{}, true
+	}
+	return nil, false
+}
@@ -21,3 +21,76 @@ type HandlerBuilder interface {
 	// appropriate handler type.
 	Build(ctx context.Context, flags map[string]Flag, args []string, resp *Response) Handler
 }
+
+// HandlerFunc is a function that fills the [Handler] interface.
+type HandlerFunc func(ctx context.Context, resp *Response)
+
+// Handle fills the [Handler] interface by calling the function.
+func (f HandlerFunc) Handle(ctx context.Context, resp *Response) {
+	f(ctx, resp)
+}
+
+// HandlerBuilderFunc is a function that fills the [HandlerBuilder] interface.
+type HandlerBuilderFunc func(ctx context.Context, flags map[string]Flag, args []string, resp *Response) Handler
+
+// Build fills the [HandlerBuilder] interface by calling the function.
+func (f HandlerBuilderFunc) Build(ctx context.Context, flags map[string]Flag, args []string, resp *Response) Handler { //nolint:ireturn // filling an interface
+	return f(ctx, flags, args, resp)
+}
+
+// Middleware wraps a [HandlerBuilder] to add behavior around building and
+// running a [Handler], like authentication checks, timing, logging, or
+// recovering from panics. The [HandlerBuilder] it returns can inspect the
+// Flags, args, and [Response] before calling next's Build, and can wrap the
+// [Handler] next returns to run code around its Handle method. The
+// [RouteResult], including the command path, is available from
+// [RouteResultFromContext].
+//
+// To stop the command from running, the [HandlerBuilder] can return a nil
+// [Handler] without calling next, after setting the [Response]'s Code or Err
+// to report why.
+type Middleware func(next HandlerBuilder) HandlerBuilder
+
+// PreRunHook is a function that runs before a [Command]'s [HandlerBuilder],
+// like the PersistentPreRun hooks on [Application] and [Command]. It receives
+// the Flags for the [Command] being run, and returns the context.Context to
+// pass to the hooks after it and to the [HandlerBuilder] and [Handler], which
+// lets it make values like loggers or API clients available to them. If it
+// returns an error, the command and any PostRunHooks don't run, and the
+// error is reported like an error in the [Response]'s Err.
+type PreRunHook func(ctx context.Context, flags map[string]Flag, resp *Response) (context.Context, error)
+
+// PostRunHook is a function that runs after a [Command]'s [Handler], like the
+// PersistentPostRun hooks on [Application] and [Command]. It runs whether the
+// [Handler] succeeded or not, and can check the [Response]'s Code and Err to
+// find out. If it returns an error and the [Response] doesn't already have
+// one, the error is recorded in the [Response]'s Err.
+type PostRunHook func(ctx context.Context, flags map[string]Flag, resp *Response) error
+
+// ErrorHandler is an alternative to [Handler] for commands that want to
+// report failure by returning an error instead of setting the [Response]'s
+// Code. Use [HandleErrors] to turn an ErrorHandler into a [Handler].
+type ErrorHandler interface {
+	// Handle is a method that will be called when the command is executed.
+	// It should contain the business logic of the command. If it returns
+	// an error, the error will be printed and mapped to the exit code.
+	Handle(ctx context.Context, resp *Response) error
+}
+
+// HandleErrors wraps an [ErrorHandler] so it can be returned from a
+// [HandlerBuilder]. Any error the [ErrorHandler] returns is recorded in the
+// [Response]'s Err.
+func HandleErrors(handler ErrorHandler) Handler { //nolint:ireturn // HandleErrors is meant to be used where a Handler is expected
+	return errorHandler{handler: handler}
+}
+
+type errorHandler struct {
+	handler ErrorHandler
+}
+
+func (handler errorHandler) Handle(ctx context.Context, resp *Response) {
+	err := handler.handler.Handle(ctx, resp)
+	if err != nil {
+		resp.Err = err
+	}
+}
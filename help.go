@@ -1,6 +1,8 @@
 package clif
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"text/tabwriter"
 )
@@ -11,6 +13,9 @@ func SubcommandsHelp(command parseable) string {
 	var builder strings.Builder
 	writer := tabwriter.NewWriter(&builder, 4, 4, 1, '\t', 0) //nolint:mnd // 4 spaces to a tab is just magic, dunno what to say
 	for _, cmd := range command.subcommands() {
+		if cmd.Hidden {
+			continue
+		}
 		writer.Write([]byte(cmd.Name + "\t" + cmd.Description + "\n")) //nolint:errcheck // error shouldn't be possible here
 	}
 	writer.Flush() //nolint:errcheck // error shouldn't be possible here
@@ -20,11 +25,220 @@ func SubcommandsHelp(command parseable) string {
 // FlagsHelp returns a default usage string for the flags defined for the
 // passed [Command] or [Application].
 func FlagsHelp(command parseable) string {
+	return flagsHelp(command.flags())
+}
+
+// flagsHelp returns a default usage string for the passed FlagDefs.
+func flagsHelp(flags []FlagDef) string {
 	var builder strings.Builder
 	writer := tabwriter.NewWriter(&builder, 4, 4, 1, '\t', 0) //nolint:mnd // 4 spaces to a tab is just magic, dunno what to say
+	for _, flag := range flags {
+		description := flag.Description
+		if flag.Default != "" {
+			description = strings.TrimSpace(description + " (default: " + flag.Default + ")")
+		}
+		if len(flag.EnvVars) > 0 {
+			description = strings.TrimSpace(description + " (env: " + strings.Join(flag.EnvVars, ", ") + ")")
+		}
+		writer.Write([]byte(flag.Name + "\t<" + flag.Parser.FlagType() + ">\t" + description + "\n")) //nolint:errcheck // error shouldn't be possible here
+	}
+	writer.Flush() //nolint:errcheck // error shouldn't be possible here
+	return builder.String()
+}
+
+// Synopsis returns a usage line for the [Command] at the end of the passed
+// command path, or for the [Application] itself if the command path is empty,
+// like "app foo bar [--quux <string>] <args...>". Each [Command] in the command
+// path should be the child of the [Command] before it.
+func Synopsis(app Application, path []Command) string {
+	var command parseable = app
+	synopsis := []string{app.name()}
+	for _, cmd := range path {
+		command = cmd
+		synopsis = append(synopsis, cmd.Name)
+	}
 	for _, flag := range command.flags() {
-		writer.Write([]byte(flag.Name + "\t<" + flag.Parser.FlagType() + ">\t" + flag.Description + "\n")) //nolint:errcheck // error shouldn't be possible here
+		name := "--" + flag.Name
+		if flag.Short != 0 {
+			name = "-" + string(flag.Short) + "|" + name
+		}
+		if flag.ValueAccepted {
+			name += " <" + flag.Parser.FlagType() + ">"
+		}
+		if !flag.Required {
+			name = "[" + name + "]"
+		}
+		synopsis = append(synopsis, name)
+	}
+	if len(command.subcommands()) > 0 {
+		synopsis = append(synopsis, "<command>")
+	}
+	cmd, ok := command.(Command)
+	switch {
+	case ok && len(cmd.Args) > 0:
+		for _, arg := range cmd.Args {
+			name := arg.Name
+			if arg.Variadic {
+				name += "..."
+			}
+			if arg.Optional {
+				synopsis = append(synopsis, "["+name+"]")
+			} else {
+				synopsis = append(synopsis, "<"+name+">")
+			}
+		}
+	case command.argsAccepted():
+		synopsis = append(synopsis, "<args...>")
+	}
+	return strings.Join(synopsis, " ")
+}
+
+// ArgsHelp returns a default usage string for the positional arguments
+// defined for the passed [Command].
+func ArgsHelp(cmd Command) string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 4, 4, 1, '\t', 0) //nolint:mnd // 4 spaces to a tab is just magic, dunno what to say
+	for _, arg := range cmd.Args {
+		writer.Write([]byte(arg.Name + "\t" + arg.Description + "\n")) //nolint:errcheck // error shouldn't be possible here
 	}
 	writer.Flush() //nolint:errcheck // error shouldn't be possible here
 	return builder.String()
 }
+
+// CommandHelp returns a default help string for the [Command] at the end of
+// the passed command path, or for the [Application] itself if the command
+// path is empty. Each [Command] in the command path should be the child of
+// the [Command] before it.
+//
+// The help string includes the [Synopsis], the description, any examples,
+// the output of [SubcommandsHelp], [ArgsHelp], and [FlagsHelp], and the
+// Persistent flags inherited from the [Command]'s parents.
+func CommandHelp(app Application, path []Command) string {
+	var command parseable = app
+	description := app.Description
+	var examples []Example
+	invocation := []string{app.name()}
+	for _, cmd := range path {
+		command = cmd
+		description = cmd.Description
+		if cmd.LongDescription != "" {
+			description = cmd.LongDescription
+		}
+		examples = cmd.Examples
+		invocation = append(invocation, cmd.Name)
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Usage: " + Synopsis(app, path) + "\n")
+	if description != "" {
+		builder.WriteString("\n" + strings.TrimSpace(description) + "\n")
+	}
+	if len(examples) > 0 {
+		builder.WriteString("\nExamples:\n")
+		for pos, example := range examples {
+			if pos > 0 {
+				builder.WriteString("\n")
+			}
+			if example.Description != "" {
+				builder.WriteString("  # " + example.Description + "\n")
+			}
+			builder.WriteString("  " + example.Invocation + "\n")
+		}
+	}
+	if subcommands := SubcommandsHelp(command); subcommands != "" {
+		builder.WriteString("\nCommands:\n" + subcommands)
+	}
+	if cmd, ok := command.(Command); ok {
+		if args := ArgsHelp(cmd); args != "" {
+			builder.WriteString("\nArguments:\n" + args)
+		}
+	}
+	if flags := FlagsHelp(command); flags != "" {
+		builder.WriteString("\nFlags:\n" + flags)
+	}
+	if flags := flagsHelp(inheritedFlagDefs(app, path)); flags != "" {
+		builder.WriteString("\nGlobal Flags:\n" + flags)
+	}
+	if len(command.subcommands()) > 0 {
+		builder.WriteString(fmt.Sprintf("\nRun \"%s <command> --help\" for more information about a command.\n", strings.Join(invocation, " ")))
+	}
+	return builder.String()
+}
+
+// helpCommandName is the name of the built-in help command, and the name of
+// the built-in help flag.
+const helpCommandName = "help"
+
+// withHelp returns a copy of the [Application] with a help command added to
+// it, unless the [Application] already has a command that the help command
+// would shadow.
+func (app Application) withHelp() Application {
+	if _, ok := findSubcommand(app, helpCommandName); ok {
+		return app
+	}
+	help := Command{
+		Name:         helpCommandName,
+		Description:  "Show help for a command.",
+		ArgsAccepted: true,
+		Handler:      helpHandlerBuilder{app: app},
+	}
+	app.Commands = append(append([]Command{}, app.Commands...), help)
+	return app
+}
+
+// helpRequest returns the command path the passed input is asking for help
+// with using the --help flag, and whether it's asking for help at all. The
+// flag is ignored after a bare -- and when the [Application] defines its own
+// help flag.
+func helpRequest(ctx context.Context, app Application, input []string) ([]Command, bool) {
+	for pos, arg := range input {
+		if arg == "--" {
+			return nil, false
+		}
+		if strings.ToLower(arg) != "--"+helpCommandName {
+			continue
+		}
+		words := append(append([]string{}, input[:pos]...), input[pos+1:]...)
+		state := walk(ctx, app, words)
+		if _, ok := findFlagDef(state.flagDefs(), helpCommandName); ok {
+			return nil, false
+		}
+		return state.path, true
+	}
+	return nil, false
+}
+
+// helpHandlerBuilder is the [HandlerBuilder] for the built-in help command. It
+// treats its arguments as the command path to show help for.
+type helpHandlerBuilder struct {
+	app Application
+}
+
+func (builder helpHandlerBuilder) Build(ctx context.Context, _ map[string]Flag, args []string, _ *Response) Handler { //nolint:ireturn // filling an interface
+	// the help command should be listed in its own output
+	app := builder.app.withHelp()
+	return helpHandler{
+		help: CommandHelp(app, walk(ctx, app, args).path),
+	}
+}
+
+// Complete fills the [Completer] interface and suggests the subcommands of the
+// command path being asked about.
+func (builder helpHandlerBuilder) Complete(ctx context.Context, _ map[string]Flag, args []string, _ string) []string {
+	var suggestions []string
+	for _, sub := range walk(ctx, builder.app, args).node.subcommands() {
+		if !sub.Hidden {
+			suggestions = append(suggestions, sub.Name)
+		}
+	}
+	return suggestions
+}
+
+// helpHandler is the [Handler] for the built-in help command and help flag.
+type helpHandler struct {
+	help string
+}
+
+func (handler helpHandler) Handle(_ context.Context, resp *Response) {
+	fmt.Fprint(resp.Output, handler.help) //nolint:errcheck // if there's an error, we can't do anything
+}
@@ -0,0 +1,129 @@
+package clif_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+func ExampleWithHelp() {
+	app := clif.Application{
+		Name:        "my-app",
+		Description: "Manages deployments.",
+		Commands: []clif.Command{
+			{
+				Name:            "deploy",
+				Description:     "Deploys a service.",
+				LongDescription: "Deploys a service to one of our regions.\n\nDeployments can be rolled back with the rollback subcommand.",
+				Examples: []clif.Example{
+					{Invocation: "my-app deploy --region us-east-1", Description: "Deploy to us-east-1."},
+					{Invocation: "my-app deploy rollback"},
+				},
+				Flags: []clif.FlagDef{
+					{
+						Name:          "region",
+						Short:         'r',
+						Description:   "The region to deploy to.",
+						ValueAccepted: true,
+						Parser:        flagtypes.StringParser{},
+					},
+					{
+						Name:          "timeout",
+						Description:   "How long to wait for the deploy.",
+						ValueAccepted: true,
+						Default:       "30s",
+						EnvVars:       []string{"DEPLOY_TIMEOUT"},
+						Parser:        flagtypes.DurationParser{},
+					},
+					{
+						Name:        "force",
+						Description: "Deploy even if checks fail.",
+						Parser:      flagtypes.BoolParser{},
+					},
+				},
+				Subcommands: []clif.Command{
+					{Name: "rollback", Description: "Rolls back a deployment."},
+					{Name: "debug", Hidden: true},
+				},
+			},
+		},
+	}
+	app.Run(context.Background(), clif.WithHelp(), clif.WithOutput(os.Stdout), clif.WithArgs([]string{"help"}))
+	app.Run(context.Background(), clif.WithHelp(), clif.WithOutput(os.Stdout), clif.WithArgs([]string{"deploy", "--region", "us", "--help"}))
+	// output:
+	// Usage: my-app <command>
+	//
+	// Manages deployments.
+	//
+	// Commands:
+	// deploy	Deploys a service.
+	// help	Show help for a command.
+	//
+	// Run "my-app <command> --help" for more information about a command.
+	// Usage: my-app deploy [-r|--region <string>] [--timeout <duration>] [--force] <command>
+	//
+	// Deploys a service to one of our regions.
+	//
+	// Deployments can be rolled back with the rollback subcommand.
+	//
+	// Examples:
+	//   # Deploy to us-east-1.
+	//   my-app deploy --region us-east-1
+	//
+	//   my-app deploy rollback
+	//
+	// Commands:
+	// rollback	Rolls back a deployment.
+	//
+	// Flags:
+	// region	<string>	The region to deploy to.
+	// timeout	<duration>	How long to wait for the deploy. (default: 30s) (env: DEPLOY_TIMEOUT)
+	// force	<bool>		Deploy even if checks fail.
+	//
+	// Run "my-app deploy <command> --help" for more information about a command.
+}
+
+func TestSynopsis(t *testing.T) {
+	t.Parallel()
+	app := clif.Application{
+		Name: "my-app",
+		Commands: []clif.Command{
+			{
+				Name: "scale",
+				Flags: []clif.FlagDef{
+					{Name: "region", Short: 'r', ValueAccepted: true, Required: true, Parser: flagtypes.StringParser{}},
+					{Name: "wait", Parser: flagtypes.BoolParser{}},
+				},
+				Args: []clif.ArgDef{
+					{Name: "service"},
+					{Name: "replicas", Optional: true},
+				},
+			},
+			{
+				Name:         "exec",
+				ArgsAccepted: true,
+			},
+			{
+				Name: "logs",
+				Args: []clif.ArgDef{{Name: "services", Optional: true, Variadic: true}},
+			},
+		},
+	}
+	cases := map[string]string{
+		"scale": "my-app scale -r|--region <string> [--wait] <service> [replicas]",
+		"exec":  "my-app exec <args...>",
+		"logs":  "my-app logs [services...]",
+	}
+	for pos, cmd := range app.Commands {
+		t.Run(cmd.Name, func(t *testing.T) {
+			t.Parallel()
+			got := clif.Synopsis(app, app.Commands[pos:pos+1])
+			if got != cases[cmd.Name] {
+				t.Errorf("Expected synopsis %q, got %q", cases[cmd.Name], got)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package clif_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+type prefixContextKey struct{}
+
+var errNoRegion = errors.New("no region configured")
+
+func ExamplePreRunHook() {
+	app := clif.Application{
+		Flags: []clif.FlagDef{
+			{Name: "verbose", Parser: flagtypes.BoolParser{}},
+		},
+		PersistentPreRun: func(ctx context.Context, flags map[string]clif.Flag, resp *clif.Response) (context.Context, error) {
+			_, verbose := flags["verbose"]
+			fmt.Fprintln(resp.Output, "app pre-run, verbose:", verbose) //nolint:errcheck // if there's an error, we can't do anything
+			return context.WithValue(ctx, prefixContextKey{}, "[app]"), nil
+		},
+		PersistentPostRun: func(_ context.Context, _ map[string]clif.Flag, resp *clif.Response) error {
+			fmt.Fprintln(resp.Output, "app post-run, code:", resp.Code) //nolint:errcheck // if there's an error, we can't do anything
+			return nil
+		},
+		Commands: []clif.Command{
+			{
+				Name: "cluster",
+				Flags: []clif.FlagDef{
+					{Name: "region", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+				},
+				PersistentPreRun: func(ctx context.Context, flags map[string]clif.Flag, _ *clif.Response) (context.Context, error) {
+					region, ok := flags["region"]
+					if !ok {
+						return ctx, errNoRegion
+					}
+					prefix, _ := ctx.Value(prefixContextKey{}).(string)
+					return context.WithValue(ctx, prefixContextKey{}, prefix+"["+region.GetRawValue()+"]"), nil
+				},
+				PersistentPostRun: func(_ context.Context, _ map[string]clif.Flag, resp *clif.Response) error {
+					fmt.Fprintln(resp.Output, "cluster post-run") //nolint:errcheck // if there's an error, we can't do anything
+					return nil
+				},
+				Subcommands: []clif.Command{
+					{
+						Name: "list",
+						Handler: funcCommandHandler(func(ctx context.Context, resp *clif.Response) {
+							prefix, _ := ctx.Value(prefixContextKey{}).(string)
+							fmt.Fprintln(resp.Output, prefix, "listing clusters") //nolint:errcheck // if there's an error, we can't do anything
+						}),
+					},
+				},
+			},
+		},
+	}
+	code := app.Run(context.Background(), clif.WithArgs([]string{"--verbose", "cluster", "--region", "us-east-1", "list"}))
+	fmt.Println(code)
+	code = app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"cluster", "list"}))
+	fmt.Println(code)
+	// output:
+	// app pre-run, verbose: true
+	// [app][us-east-1] listing clusters
+	// cluster post-run
+	// app post-run, code: 0
+	// 0
+	// app pre-run, verbose: false
+	// no region configured
+	// 1
+}
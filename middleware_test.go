@@ -0,0 +1,111 @@
+package clif_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+var errUnauthorized = errors.New("unauthorized: --token is required")
+
+// logCalls is a Middleware that logs the command path before the Handler runs
+// and the exit code after it's done.
+func logCalls(next clif.HandlerBuilder) clif.HandlerBuilder { //nolint:ireturn // Middleware signature
+	return clif.HandlerBuilderFunc(func(ctx context.Context, flags map[string]clif.Flag, args []string, resp *clif.Response) clif.Handler {
+		handler := next.Build(ctx, flags, args, resp)
+		if handler == nil {
+			return nil
+		}
+		result, _ := clif.RouteResultFromContext(ctx)
+		names := make([]string, 0, len(result.CommandPath))
+		for _, cmd := range result.CommandPath {
+			names = append(names, cmd.Name)
+		}
+		return clif.HandlerFunc(func(ctx context.Context, resp *clif.Response) {
+			fmt.Fprintln(resp.Output, "running", strings.Join(names, " ")) //nolint:errcheck // if there's an error, we can't do anything
+			handler.Handle(ctx, resp)
+			fmt.Fprintln(resp.Output, "exited with", resp.Code) //nolint:errcheck // if there's an error, we can't do anything
+		})
+	})
+}
+
+// requireToken is a Middleware that stops the command from running if the
+// --token flag isn't set.
+func requireToken(next clif.HandlerBuilder) clif.HandlerBuilder { //nolint:ireturn // Middleware signature
+	return clif.HandlerBuilderFunc(func(ctx context.Context, flags map[string]clif.Flag, args []string, resp *clif.Response) clif.Handler {
+		if _, ok := flags["token"]; !ok {
+			resp.Err = errUnauthorized
+			return nil
+		}
+		return next.Build(ctx, flags, args, resp)
+	})
+}
+
+// recoverPanics is a Middleware that turns panics in the Handler into errors.
+func recoverPanics(next clif.HandlerBuilder) clif.HandlerBuilder { //nolint:ireturn // Middleware signature
+	return clif.HandlerBuilderFunc(func(ctx context.Context, flags map[string]clif.Flag, args []string, resp *clif.Response) clif.Handler {
+		handler := next.Build(ctx, flags, args, resp)
+		if handler == nil {
+			return nil
+		}
+		return clif.HandlerFunc(func(ctx context.Context, resp *clif.Response) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					resp.Err = clif.ExitError{Code: 70, Err: fmt.Errorf("panic: %v", recovered)} //nolint:err113 // wrapping an arbitrary panic value
+				}
+			}()
+			handler.Handle(ctx, resp)
+		})
+	})
+}
+
+func ExampleMiddleware() {
+	app := clif.Application{
+		Middleware: []clif.Middleware{logCalls, recoverPanics},
+		Commands: []clif.Command{
+			{
+				Name:       "admin",
+				Middleware: []clif.Middleware{requireToken},
+				Flags: []clif.FlagDef{
+					{Name: "token", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+				},
+				Subcommands: []clif.Command{
+					{
+						Name: "purge",
+						Handler: funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+							fmt.Fprintln(resp.Output, "purged") //nolint:errcheck // if there's an error, we can't do anything
+						}),
+					},
+				},
+			},
+			{
+				Name: "crash",
+				Handler: funcCommandHandler(func(_ context.Context, _ *clif.Response) {
+					panic("oops")
+				}),
+			},
+		},
+	}
+	code := app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"admin", "--token", "secret", "purge"}))
+	fmt.Println(code)
+	code = app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"admin", "purge"}))
+	fmt.Println(code)
+	code = app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"crash"}))
+	fmt.Println(code)
+	// output:
+	// running admin purge
+	// purged
+	// exited with 0
+	// 0
+	// unauthorized: --token is required
+	// 1
+	// running crash
+	// exited with 0
+	// panic: oops
+	// 70
+}
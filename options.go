@@ -2,6 +2,8 @@ package clif
 
 import (
 	"io"
+	"reflect"
+	"time"
 )
 
 // RunOptions holds all the options to pass to [Application.Run]. It should be
@@ -18,6 +20,47 @@ type RunOptions struct {
 	// Args are the arguments that were passed to the command. Defaults
 	// to os.Args[1:].
 	Args []string
+
+	// Help indicates whether the application should provide a built-in
+	// help command and --help flag. Defaults to false.
+	Help bool
+
+	// ExitCode maps errors to the status code the command should exit
+	// with. Defaults to DefaultExitCode.
+	ExitCode func(error) int
+
+	// UsageError presents errors routing the input to a command to the
+	// user, and returns the status code the command should exit with.
+	// Defaults to PresentUsageError.
+	UsageError func(resp *Response, err UsageError) int
+
+	// OutputFormats indicates whether the application should provide a
+	// persistent --output flag that controls the format Response.Render
+	// writes data in. Defaults to false.
+	OutputFormats bool
+
+	// TableFlags indicates whether the application should provide
+	// persistent --columns, --sort-by, and --no-headers flags that control
+	// how Response.NewTable writes tables. Defaults to false.
+	TableFlags bool
+
+	// HandleSignals indicates whether the application should cancel the
+	// context.Context passed to the command when the process receives
+	// SIGINT or SIGTERM, and exit immediately when it receives a second
+	// one. Defaults to false.
+	HandleSignals bool
+
+	// ShutdownTimeout limits how long cleanup functions registered with
+	// AddCleanup or Provide have to finish. Defaults to 0, which waits as
+	// long as they take.
+	ShutdownTimeout time.Duration
+
+	// exit is used to exit the process when a second signal is received.
+	exit func(code int)
+
+	// services holds the constructors for the services registered with
+	// Provide, keyed by the type of service they construct.
+	services map[reflect.Type]serviceConstructor
 }
 
 // RunOption is a function type that modifies a passed [RunOptions] when
@@ -47,3 +90,80 @@ func WithArgs(args []string) RunOption {
 		opts.Args = args
 	}
 }
+
+// WithHelp is a [RunOption] that adds a built-in help command and a --help
+// flag on every command to the application. Both print the [CommandHelp] for
+// the command path they're asking about. Applications that define their own
+// help command or help flag will have those used instead.
+func WithHelp() RunOption {
+	return func(opts *RunOptions) {
+		opts.Help = true
+	}
+}
+
+// WithExitCodes is a [RunOption] that sets the function used to map errors
+// to the status code the command exits with. The function will be called with
+// the error recorded in the [Response]'s Err, and any errors routing the
+// input that aren't about the user's input, like a configuration file that
+// can't be loaded. Errors in the user's input are passed to the function set
+// by [WithUsageErrors] instead, which decides the status code itself.
+func WithExitCodes(mapper func(error) int) RunOption {
+	return func(opts *RunOptions) {
+		opts.ExitCode = mapper
+	}
+}
+
+// WithUsageErrors is a [RunOption] that sets the function used to present
+// errors routing the input to a command to the user. The function returns the
+// status code the command should exit with.
+func WithUsageErrors(presenter func(resp *Response, err UsageError) int) RunOption {
+	return func(opts *RunOptions) {
+		opts.UsageError = presenter
+	}
+}
+
+// WithSignalHandling is a [RunOption] that cancels the context.Context passed
+// to the command when the process receives SIGINT or SIGTERM, so long-running
+// commands can stop gracefully. A second signal exits the process
+// immediately. Once the command returns, cleanup functions registered with
+// [AddCleanup] or [Provide] are given the passed timeout to finish, and the
+// command exits with the conventional status code for the signal: 130 for
+// SIGINT and 143 for SIGTERM.
+func WithSignalHandling(timeout time.Duration) RunOption {
+	return func(opts *RunOptions) {
+		opts.HandleSignals = true
+		opts.ShutdownTimeout = timeout
+	}
+}
+
+// WithOutputFormats is a [RunOption] that adds a persistent --output flag, with
+// the short name -o, to the application, which can be used after any command
+// name to choose the [OutputFormat] [Response.Render] writes data in. The
+// value is one of table, json, ndjson, csv, or go-template=<template>.
+// Commands that define their own output flag, and their subcommands, will
+// have it used instead, and Render will use the default format for them.
+//
+// When the value is the last word of the input, it's only treated as the
+// flag's value if the command has no room for another positional argument,
+// like a command with Args that already has all of them. Commands that accept
+// arguments without defining Args need the value attached there, as in
+// -ojson or --output=json.
+func WithOutputFormats() RunOption {
+	return func(opts *RunOptions) {
+		opts.OutputFormats = true
+	}
+}
+
+// WithTableFlags is a [RunOption] that adds persistent --columns, --sort-by,
+// and --no-headers flags to the application, which can be used after any
+// command name to control how the tables created by [Response.NewTable] and
+// written by [Response.Render] are written. --columns takes a comma-separated
+// list of column names, and can be repeated. --sort-by takes a column name,
+// prefixed with - to sort in descending order. Commands that define their own
+// flags with any of those names, and their subcommands, will have them used
+// instead.
+func WithTableFlags() RunOption {
+	return func(opts *RunOptions) {
+		opts.TableFlags = true
+	}
+}
@@ -0,0 +1,357 @@
+package clif
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// OutputFormat identifies a format [Response.Render] can write data in.
+type OutputFormat string
+
+const (
+	// OutputTable renders data as a table for people to read, with a
+	// row for each item and a column for each field.
+	OutputTable OutputFormat = "table"
+
+	// OutputJSON renders data as indented JSON.
+	OutputJSON OutputFormat = "json"
+
+	// OutputNDJSON renders data as newline-delimited JSON, with each item
+	// on its own line.
+	OutputNDJSON OutputFormat = "ndjson"
+
+	// OutputCSV renders data as CSV, with a header row naming each field
+	// and a row for each item.
+	OutputCSV OutputFormat = "csv"
+
+	// OutputTemplate renders data using a text/template, which is set
+	// with the output flag as go-template=<template>.
+	OutputTemplate OutputFormat = "go-template"
+)
+
+// outputFormats lists the formats the output flag accepts, in the order
+// they're suggested in.
+var outputFormats = []OutputFormat{OutputTable, OutputJSON, OutputNDJSON, OutputCSV, OutputTemplate}
+
+// outputFlagName is the name of the flag added by [WithOutputFormats].
+const outputFlagName = "output"
+
+// UnsupportedOutputFormatError is returned when data is rendered in an
+// [OutputFormat] that isn't supported. The underlying string is the format.
+type UnsupportedOutputFormatError string
+
+func (err UnsupportedOutputFormatError) Error() string {
+	quoted := make([]string, 0, len(outputFormats))
+	for _, format := range outputFormats {
+		quoted = append(quoted, string(format))
+	}
+	return fmt.Sprintf("unsupported output format %q, expected one of %s", string(err), strings.Join(quoted, ", "))
+}
+
+// Render writes the passed data to the [Response]'s Output in its
+// OutputFormat, which is set by the output flag added by [WithOutputFormats],
+// defaulting to [OutputTable].
+//
+// The data can be a struct, a map with string keys, or any other value, or a
+// slice of them. When rendering tables and CSV, each item in a slice is a
+// row. Structs have a column for each exported field, named after the field
+// or its json struct tag, and fields tagged `json:"-"` are skipped. Maps have
+// a column for each key, and other values have a single column named VALUE.
+// Tables are written with [Response.NewTable], so they follow the
+// [Response]'s TableOptions, and columns holding only numbers are aligned to
+// the right.
+// The JSON formats use [encoding/json], and [OutputTemplate] executes the
+// template with the data as it was passed.
+func (resp *Response) Render(data any) error {
+	switch resp.OutputFormat {
+	case "", OutputTable:
+		return resp.renderTable(data)
+	case OutputJSON:
+		encoder := json.NewEncoder(resp.Output)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	case OutputNDJSON:
+		encoder := json.NewEncoder(resp.Output)
+		for _, item := range renderItems(data) {
+			var value any
+			if item.IsValid() {
+				value = item.Interface()
+			}
+			err := encoder.Encode(value)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case OutputCSV:
+		headers, rows := tabulate(data)
+		writer := csv.NewWriter(resp.Output)
+		err := writer.Write(headers)
+		if err != nil {
+			return err
+		}
+		return writer.WriteAll(rows)
+	case OutputTemplate:
+		tmpl, err := template.New(string(OutputTemplate)).Parse(resp.OutputTemplate)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(resp.Output, data)
+	}
+	return UnsupportedOutputFormatError(resp.OutputFormat)
+}
+
+// renderTable writes the passed data as a [Table], right-aligning columns
+// that only hold numbers.
+func (resp *Response) renderTable(data any) error {
+	headers, rows := tabulate(data)
+	columns := make([]Column, 0, len(headers))
+	for pos, header := range headers {
+		column := Column{Name: strings.ToUpper(header)}
+		if numericColumn(rows, pos) {
+			column.Align = AlignRight
+		}
+		columns = append(columns, column)
+	}
+	table := resp.NewTable(columns...)
+	for _, row := range rows {
+		table.AddRow(row...)
+	}
+	return table.Flush()
+}
+
+// numericColumn returns true if every cell in the passed column is a number,
+// ignoring empty cells, and there's at least one number.
+func numericColumn(rows [][]string, column int) bool {
+	var numbers int
+	for _, row := range rows {
+		if row[column] == "" {
+			continue
+		}
+		if _, err := strconv.ParseFloat(row[column], 64); err != nil {
+			return false
+		}
+		numbers++
+	}
+	return numbers > 0
+}
+
+// renderItems returns the items in the passed data: each element if it's a
+// slice or array, or the data itself otherwise.
+func renderItems(data any) []reflect.Value {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Type().Elem().Kind() == reflect.Uint8 {
+		return []reflect.Value{reflect.ValueOf(data)}
+	}
+	items := make([]reflect.Value, 0, value.Len())
+	for pos := range value.Len() {
+		items = append(items, value.Index(pos))
+	}
+	return items
+}
+
+// itemType returns the type of the items in the passed data, as split up by
+// renderItems, with any pointers dereferenced.
+func itemType(data any) reflect.Type {
+	typ := reflect.TypeOf(data)
+	if typ == nil {
+		return nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) && typ.Elem().Kind() != reflect.Uint8 {
+		typ = typ.Elem()
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// tabulate splits the passed data into column names and rows of formatted
+// values.
+func tabulate(data any) ([]string, [][]string) {
+	items := renderItems(data)
+	typ := itemType(data)
+	switch {
+	case typ != nil && typ.Kind() == reflect.Struct && typ != reflect.TypeFor[time.Time]():
+		var headers []string
+		var fields []int
+		for pos := range typ.NumField() {
+			field := typ.Field(pos)
+			name, ok := columnName(field)
+			if !ok {
+				continue
+			}
+			headers = append(headers, name)
+			fields = append(fields, pos)
+		}
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			item = derefValue(item)
+			row := make([]string, 0, len(fields))
+			for _, pos := range fields {
+				if !item.IsValid() {
+					row = append(row, "")
+					continue
+				}
+				row = append(row, formatCell(item.Field(pos)))
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows
+	case typ != nil && typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String:
+		var headers []string
+		for _, item := range items {
+			item = derefValue(item)
+			if !item.IsValid() {
+				continue
+			}
+			for _, key := range item.MapKeys() {
+				if !slices.Contains(headers, key.String()) {
+					headers = append(headers, key.String())
+				}
+			}
+		}
+		slices.Sort(headers)
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			item = derefValue(item)
+			row := make([]string, 0, len(headers))
+			for _, header := range headers {
+				if !item.IsValid() {
+					row = append(row, "")
+					continue
+				}
+				row = append(row, formatCell(item.MapIndex(reflect.ValueOf(header).Convert(typ.Key()))))
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{formatCell(item)})
+	}
+	return []string{"value"}, rows
+}
+
+// columnName returns the name of the column for the passed struct field, and
+// false if the field shouldn't have a column.
+func columnName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if tag == "-" {
+		return "", false
+	}
+	if tag != "" {
+		return tag, true
+	}
+	return field.Name, true
+}
+
+// derefValue follows pointers and interfaces to the value they hold,
+// returning an invalid [reflect.Value] if any of them are nil.
+func derefValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// formatCell formats the passed value for use in a table or CSV cell.
+func formatCell(value reflect.Value) string {
+	value = derefValue(value)
+	if !value.IsValid() {
+		return ""
+	}
+	if timestamp, ok := value.Interface().(time.Time); ok {
+		return timestamp.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// outputFormatFlag is the [Flag] for the output flag added by
+// [WithOutputFormats].
+type outputFormatFlag struct {
+	name     string
+	rawValue string
+	format   OutputFormat
+	template string
+}
+
+func (flag outputFormatFlag) GetName() string     { return flag.name }
+func (flag outputFormatFlag) GetRawValue() string { return flag.rawValue }
+func (flag outputFormatFlag) GetValue() any       { return flag.format }
+
+// outputFormatParser is the [FlagParser] for the output flag added by
+// [WithOutputFormats].
+type outputFormatParser struct{}
+
+func (outputFormatParser) Parse(_ context.Context, name, value string, _ Flag) (Flag, error) { //nolint:ireturn // FlagParser interface requires returning an interface
+	format, tmpl, _ := strings.Cut(value, "=")
+	if !slices.Contains(outputFormats, OutputFormat(format)) || (format == string(OutputTemplate)) != (tmpl != "") {
+		return nil, UnsupportedOutputFormatError(value)
+	}
+	return outputFormatFlag{name: name, rawValue: value, format: OutputFormat(format), template: tmpl}, nil
+}
+
+func (outputFormatParser) FlagType() string {
+	return "format"
+}
+
+// Complete fills the [Completer] interface and suggests the output formats.
+func (outputFormatParser) Complete(_ context.Context, _ map[string]Flag, _ []string, _ string) []string {
+	suggestions := make([]string, 0, len(outputFormats))
+	for _, format := range outputFormats {
+		if format == OutputTemplate {
+			suggestions = append(suggestions, string(format)+"=")
+			continue
+		}
+		suggestions = append(suggestions, string(format))
+	}
+	return suggestions
+}
+
+// withOutputFormats returns a copy of the [Application] with the output flag
+// added to it, except for the Commands that define a flag it would conflict
+// with.
+func (app Application) withOutputFormats() Application {
+	return app.withBuiltinFlag(FlagDef{
+		Name:          outputFlagName,
+		Short:         'o',
+		Description:   "The format to write output in: table, json, ndjson, csv, or go-template=<template>.",
+		ValueAccepted: true,
+		Persistent:    true,
+		Default:       string(OutputTable),
+		Parser:        outputFormatParser{},
+	})
+}
+
+// setOutputFormat sets the [Response]'s OutputFormat and OutputTemplate from
+// the output flag added by [WithOutputFormats], if it's set.
+func (resp *Response) setOutputFormat(flags map[string]Flag) {
+	flag, ok := flags[outputFlagName].(outputFormatFlag)
+	if !ok {
+		return
+	}
+	resp.OutputFormat = flag.format
+	resp.OutputTemplate = flag.template
+}
@@ -0,0 +1,179 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+type service struct {
+	Name     string    `json:"name"`
+	Replicas int       `json:"replicas"`
+	Updated  time.Time `json:"updated"`
+	Secret   string    `json:"-"`
+}
+
+func ExampleResponse_Render() {
+	app := clif.Application{
+		Name:      "my-app",
+		LookupEnv: func(string) (string, bool) { return "", false },
+		Commands: []clif.Command{
+			{
+				Name: "services",
+				Subcommands: []clif.Command{
+					{
+						Name: "list",
+						Handler: funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+							updated := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+							resp.Err = resp.Render([]service{
+								{Name: "api", Replicas: 3, Updated: updated, Secret: "hunter2"},
+								{Name: "web", Replicas: 12, Updated: updated},
+							})
+						}),
+					},
+				},
+			},
+		},
+	}
+	for _, args := range [][]string{
+		{"services", "list"},
+		{"services", "list", "-o", "json"},
+		{"services", "list", "--output=ndjson"},
+		{"-o", "csv", "services", "list"},
+		{"services", "list", "-o", "go-template={{range .}}{{.Name}}: {{.Replicas}}\n{{end}}"},
+		{"services", "list", "-o", "yaml"},
+	} {
+		code := app.Run(context.Background(), clif.WithOutputFormats(), clif.WithError(os.Stdout), clif.WithArgs(args))
+		fmt.Println(code)
+	}
+	// output:
+	// NAME  REPLICAS  UPDATED
+	// api          3  2024-03-01T12:00:00Z
+	// web         12  2024-03-01T12:00:00Z
+	// 0
+	// [
+	//   {
+	//     "name": "api",
+	//     "replicas": 3,
+	//     "updated": "2024-03-01T12:00:00Z"
+	//   },
+	//   {
+	//     "name": "web",
+	//     "replicas": 12,
+	//     "updated": "2024-03-01T12:00:00Z"
+	//   }
+	// ]
+	// 0
+	// {"name":"api","replicas":3,"updated":"2024-03-01T12:00:00Z"}
+	// {"name":"web","replicas":12,"updated":"2024-03-01T12:00:00Z"}
+	// 0
+	// name,replicas,updated
+	// api,3,2024-03-01T12:00:00Z
+	// web,12,2024-03-01T12:00:00Z
+	// 0
+	// api: 3
+	// web: 12
+	// 0
+	// unsupported output format "yaml", expected one of table, json, ndjson, csv, go-template
+	//
+	// Usage: my-app services list
+	//
+	// Global Flags:
+	// output	<format>	The format to write output in: table, json, ndjson, csv, or go-template=<template>. (default: table)
+	// 2
+}
+
+func outputConflictTestApp() clif.Application {
+	ownOutput := funcCommandHandler(func(ctx context.Context, resp *clif.Response) {
+		result, _ := clif.RouteResultFromContext(ctx)
+		fmt.Fprintf(resp.Output, "own output %q, format %q\n", result.Flags["output"].GetRawValue(), resp.OutputFormat) //nolint:errcheck // if there's an error, we can't do anything
+	})
+	format := funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+		fmt.Fprintf(resp.Output, "format %q\n", resp.OutputFormat) //nolint:errcheck // if there's an error, we can't do anything
+	})
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "get",
+				Flags: []clif.FlagDef{
+					{Name: "output", ValueAccepted: true, OnlyAfterCommandName: true, Parser: flagtypes.StringParser{}},
+				},
+				Handler: ownOutput,
+			},
+			{
+				Name: "export",
+				Flags: []clif.FlagDef{
+					{Name: "output", Short: 'o', ValueAccepted: true, Parser: flagtypes.StringParser{}},
+				},
+				Handler: ownOutput,
+			},
+			{
+				Name:    "deploy",
+				Args:    []clif.ArgDef{{Name: "service"}},
+				Handler: format,
+			},
+			{
+				Name: "cluster",
+				Subcommands: []clif.Command{
+					{Name: "list", Handler: format},
+				},
+			},
+		},
+	}
+}
+
+func TestWithOutputFormatsConflicts(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		input          []string
+		expectedOutput string
+	}
+
+	cases := map[string]testCase{
+		"own-only-after-command-name": {
+			input:          []string{"get", "--output", "x"},
+			expectedOutput: "own output \"x\", format \"\"\n",
+		},
+		"own": {
+			input:          []string{"export", "-o", "y"},
+			expectedOutput: "own output \"y\", format \"\"\n",
+		},
+		"built-in": {
+			input:          []string{"deploy", "svc", "-o", "json"},
+			expectedOutput: "format \"json\"\n",
+		},
+		"built-in-long": {
+			input:          []string{"deploy", "--output", "csv", "svc"},
+			expectedOutput: "format \"csv\"\n",
+		},
+		"built-in-default": {
+			input:          []string{"deploy", "svc"},
+			expectedOutput: "format \"table\"\n",
+		},
+		"built-in-subcommand": {
+			input:          []string{"cluster", "list", "-o", "ndjson"},
+			expectedOutput: "format \"ndjson\"\n",
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var output, errOutput bytes.Buffer
+			code := outputConflictTestApp().Run(context.Background(), clif.WithOutputFormats(), clif.WithOutput(&output), clif.WithError(&errOutput), clif.WithArgs(testCase.input))
+			if code != 0 {
+				t.Errorf("Expected exit code 0, got %d: %s", code, errOutput.String())
+			}
+			if diff := cmp.Diff(testCase.expectedOutput, output.String()); diff != "" {
+				t.Errorf("Unexpected diff comparing output (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
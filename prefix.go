@@ -0,0 +1,84 @@
+package clif
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AmbiguousPrefixError is returned when prefix matching is enabled and the
+// input is the prefix of more than one subcommand or flag, so it's not clear
+// which one was meant.
+type AmbiguousPrefixError struct {
+	// Prefix is the input that matched more than one name.
+	Prefix string
+
+	// Candidates are the names the input could have meant.
+	Candidates []string
+}
+
+func (err AmbiguousPrefixError) Error() string {
+	quoted := make([]string, 0, len(err.Candidates))
+	for _, candidate := range err.Candidates {
+		quoted = append(quoted, strconv.Quote(candidate))
+	}
+	return fmt.Sprintf("%q is ambiguous, it could mean %s", err.Prefix, strings.Join(quoted, " or "))
+}
+
+// matchPrefix returns the index of the only one of the passed groups of names
+// with a name that starts with prefix, or -1 if none of them do. Each group is
+// a set of equivalent names, like a name followed by its aliases. If more than
+// one group matches, an [AmbiguousPrefixError] listing the first name of each
+// matching group is returned. An empty prefix never matches.
+func matchPrefix(prefix string, candidates [][]string) (int, error) {
+	if prefix == "" {
+		return -1, nil
+	}
+	prefix = strings.ToLower(prefix)
+	match := -1
+	var matches []string
+	for pos, group := range candidates {
+		for _, name := range group {
+			if strings.HasPrefix(strings.ToLower(name), prefix) {
+				match = pos
+				matches = append(matches, group[0])
+				break
+			}
+		}
+	}
+	if len(matches) > 1 {
+		return -1, AmbiguousPrefixError{Prefix: prefix, Candidates: matches}
+	}
+	return match, nil
+}
+
+// prefixSubcommand returns the subcommand of the passed [Command] or
+// [Application] whose name or one of its aliases starts with prefix. Hidden
+// subcommands are never matched by prefix.
+func prefixSubcommand(command parseable, prefix string) (Command, bool, error) {
+	var visible []Command
+	for _, sub := range command.subcommands() {
+		if !sub.Hidden {
+			visible = append(visible, sub)
+		}
+	}
+	match, err := matchPrefix(prefix, subcommandNames(command))
+	if err != nil || match < 0 {
+		return Command{}, false, err
+	}
+	return visible[match], true, nil
+}
+
+// prefixFlagDef returns the [FlagDef] whose name or one of its aliases starts
+// with prefix, which shouldn't include the leading --. An empty prefix never
+// matches.
+func prefixFlagDef(flags []FlagDef, prefix string) (FlagDef, bool, error) {
+	if prefix == "" {
+		return FlagDef{}, false, nil
+	}
+	match, err := matchPrefix("--"+prefix, flagNames(flags))
+	if err != nil || match < 0 {
+		return FlagDef{}, false, err
+	}
+	return flags[match], true, nil
+}
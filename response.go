@@ -9,6 +9,12 @@ type Response struct {
 	// Code is the status code the command will exit with.
 	Code int
 
+	// Err is the error the command failed with, if any. If Err is set
+	// after the HandlerBuilder or Handler run, it will be written to
+	// Error and the command will exit with the status code the
+	// RunOptions' ExitCode function maps it to, instead of Code.
+	Err error
+
 	// Output is the writer that should be used for command output. It will
 	// usually be set to the shell's standard output.
 	Output io.Writer
@@ -16,4 +22,18 @@ type Response struct {
 	// Error is the writer that should be used to communicate error
 	// conditions. It will usually be set to the shell's standard error.
 	Error io.Writer
+
+	// OutputFormat is the format Render writes data in. It's set from the
+	// output flag added by WithOutputFormats, and defaults to
+	// OutputTable.
+	OutputFormat OutputFormat
+
+	// OutputTemplate is the text/template Render uses when OutputFormat
+	// is OutputTemplate.
+	OutputTemplate string
+
+	// TableOptions controls how the tables created by NewTable are
+	// written. It's set from the flags added by WithTableFlags and the
+	// width of the terminal Output is written to, if it is one.
+	TableOptions TableOptions
 }
@@ -27,17 +27,14 @@ type ExtraInputError struct {
 }
 
 func (err ExtraInputError) Error() string {
-	var commandPath []string
-	for _, cmd := range err.CommandPath {
-		commandPath = append(commandPath, cmd.Name)
-	}
-	return fmt.Sprintf("unexpected extra input to %s: %s", strings.Join(commandPath, " "), strings.Join(err.ExtraInput, " "))
+	return fmt.Sprintf("unexpected extra input to %s: %s", commandPathName(err.CommandPath), strings.Join(err.ExtraInput, " "))
 }
 
 type parseable interface {
 	subcommands() []Command
 	flags() []FlagDef
 	argsAccepted() bool
+	argDefs() []ArgDef
 }
 
 // RouteResult holds information about the [Command] that should be run and the
@@ -45,21 +42,35 @@ type parseable interface {
 type RouteResult struct {
 	// Command is the Command that Route believes should be run.
 	Command Command
+	// CommandPath is the Commands, in order, that were matched, ending
+	// with Command. Each Command in the slice is the child of the Command
+	// before it in the slice.
+	CommandPath []Command
 	// Flags are the Flags that should be applied to that command.
 	Flags map[string]Flag
 	// Args are the positional arguments that should be passed to that
 	// command.
 	Args []string
+	// ParsedArgs holds the positional arguments that were parsed by the
+	// Parser of their ArgDef in the Command's Args, keyed by the ArgDef's
+	// Name. Variadic arguments are parsed into a single Flag.
+	ParsedArgs map[string]Flag
+	// Sources records where the value of each of the Flags came from,
+	// using the same keys as Flags.
+	Sources map[string]FlagSource
 }
 
 // Route parses the passed input in the context of the passed [Application],
-// turning it into a [Command] with Flags and arguments.
+// turning it into a [Command] with Flags and arguments. If an error is
+// returned, the [RouteResult]'s CommandPath holds the Commands that were
+// matched before the error was encountered.
 func Route(ctx context.Context, root Application, input []string) (RouteResult, error) {
 	result := RouteResult{
-		Flags: map[string]Flag{},
+		Flags:   map[string]Flag{},
+		Sources: map[string]FlagSource{},
 	}
 	var cmdPath []Command
-	parsed, err := parse(ctx, root, input, false)
+	parsed, err := parse(ctx, root, input, parseOptions{prefixMatching: root.PrefixMatching})
 	if err != nil {
 		return result, err
 	}
@@ -68,7 +79,13 @@ func Route(ctx context.Context, root Application, input []string) (RouteResult,
 	for parsed.subcommand != nil {
 		result.Command = *parsed.subcommand
 		cmdPath = append(cmdPath, *parsed.subcommand)
-		parsed, err = parse(ctx, parsed.subcommand, parsed.unparsed, result.Command.AllowNonFlagFlags)
+		result.CommandPath = cmdPath
+		parsed, err = parse(ctx, parsed.subcommand, parsed.unparsed, parseOptions{
+			allowNonFlagFlags: result.Command.AllowNonFlagFlags,
+			prefixMatching:    root.PrefixMatching,
+			inherited:         inheritedFlagDefs(root, cmdPath),
+			prior:             result.Flags,
+		})
 		if err != nil {
 			return result, err
 		}
@@ -84,5 +101,155 @@ func Route(ctx context.Context, root Application, input []string) (RouteResult,
 			ExtraInput:  parsed.unparsed,
 		}
 	}
+	for name := range result.Flags {
+		result.Sources[name] = FlagSourceArgs
+	}
+	err = applyFlagFallbacks(ctx, root, cmdPath, &result)
+	if err != nil {
+		return result, fallbackError{err: err}
+	}
+	err = validate(root, cmdPath, result)
+	if err != nil {
+		return result, err
+	}
+	result.ParsedArgs, err = parseArgs(ctx, result.Command.Args, result.Args)
+	if err != nil {
+		return result, err
+	}
 	return result, nil
 }
+
+// fallbackError wraps errors filling in Flags from their fallback sources,
+// like a configuration file that can't be loaded or a Default that can't be
+// parsed. They aren't mistakes in the user's input, so [Application.Run]
+// doesn't present them as a [UsageError].
+type fallbackError struct {
+	err error
+}
+
+func (err fallbackError) Error() string {
+	return err.err.Error()
+}
+
+func (err fallbackError) Unwrap() error {
+	return err.err
+}
+
+type routeResultContextKey struct{}
+
+// RouteResultFromContext returns the [RouteResult] for the [Command] being run
+// by [Application.Run], which makes it available to the [HandlerBuilder] and
+// [Handler] through their context.Context.
+func RouteResultFromContext(ctx context.Context) (RouteResult, bool) {
+	result, ok := ctx.Value(routeResultContextKey{}).(RouteResult)
+	return result, ok
+}
+
+// validate checks that the required flags defined on the passed [Application]
+// and command path have values, and that the [Command] being run got the
+// number of arguments it expects.
+func validate(root Application, cmdPath []Command, result RouteResult) error {
+	defs := append([]FlagDef{}, root.Flags...)
+	for _, cmd := range cmdPath {
+		defs = append(defs, cmd.Flags...)
+	}
+	for _, def := range defs {
+		if def.Required && !def.isSet(result.Flags) {
+			return MissingRequiredFlagError{CommandPath: cmdPath, Flag: strings.ToLower(def.Name)}
+		}
+	}
+	if len(result.Command.Args) < 1 {
+		return nil
+	}
+	minArgs, maxArgs := argCounts(result.Command.Args)
+	if len(result.Args) < minArgs || (maxArgs >= 0 && len(result.Args) > maxArgs) {
+		return ArgCountError{CommandPath: cmdPath, Min: minArgs, Max: maxArgs, Got: len(result.Args)}
+	}
+	return nil
+}
+
+// applyFlagFallbacks fills in the Flags that weren't included in the input
+// from their fallback sources, for every flag defined on the passed
+// [Application] and command path. The environment takes precedence over the
+// [Application]'s Config, which takes precedence over the [FlagDef]'s
+// Default.
+func applyFlagFallbacks(ctx context.Context, root Application, cmdPath []Command, result *RouteResult) error {
+	var config Config
+	if root.Config != nil {
+		var err error
+		config, err = root.Config.LoadConfig(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	defs := append([]FlagDef{}, root.Flags...)
+	for _, cmd := range cmdPath {
+		defs = append(defs, cmd.Flags...)
+	}
+	for _, def := range defs {
+		if def.isSet(result.Flags) {
+			continue
+		}
+		flag, source, err := fallbackFlag(ctx, root, config, cmdPath, def)
+		if err != nil {
+			return err
+		}
+		if flag != nil {
+			result.Flags[flag.GetName()] = flag
+			result.Sources[flag.GetName()] = source
+		}
+	}
+	return nil
+}
+
+// fallbackFlag returns the [Flag] for the passed [FlagDef] from the first of
+// its fallback sources that has a value for it, and which source that was. If
+// none of them have a value, the returned [Flag] is nil.
+func fallbackFlag(ctx context.Context, root Application, config Config, cmdPath []Command, def FlagDef) (Flag, FlagSource, error) { //nolint:ireturn // Flag is the interface we're returning
+	name := strings.ToLower(def.Name)
+	flag, err := envFlag(ctx, root, def)
+	if err != nil || flag != nil {
+		return flag, FlagSourceEnv, err
+	}
+	for _, key := range append([]string{name}, def.Aliases...) {
+		values, ok := config.lookup(cmdPath, key)
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			flag, err = def.Parser.Parse(ctx, name, value, flag)
+			if err != nil {
+				return nil, "", InvalidConfigValueError{Flag: name, Err: err}
+			}
+		}
+		if flag != nil {
+			return flag, FlagSourceConfig, nil
+		}
+	}
+	if def.Default != "" {
+		flag, err = def.Parser.Parse(ctx, name, def.Default, nil)
+		if err != nil {
+			return nil, "", InvalidDefaultError{Flag: name, Err: err}
+		}
+		return flag, FlagSourceDefault, nil
+	}
+	return nil, "", nil
+}
+
+// envFlag returns the [Flag] set by the first of the passed [FlagDef]'s
+// EnvVars that is set, or nil if none of them are set.
+func envFlag(ctx context.Context, root Application, def FlagDef) (Flag, error) { //nolint:ireturn // Flag is the interface we're returning
+	name := strings.ToLower(def.Name)
+	for _, envVar := range def.EnvVars {
+		value, ok := root.lookupEnv(envVar)
+		if !ok {
+			continue
+		}
+		flag, err := def.Parser.Parse(ctx, name, value, nil)
+		if err != nil {
+			return nil, InvalidEnvVarError{Flag: name, EnvVar: envVar, Err: err}
+		}
+		return flag, nil
+	}
+	return nil, nil //nolint:nilnil // no flag set isn't an error
+}
@@ -3,7 +3,9 @@ package clif_test
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"impractical.co/clif"
@@ -18,6 +20,8 @@ func TestRoute(t *testing.T) {
 		expectedCmdName string
 		expectedFlags   map[string]clif.Flag
 		expectedArgs    []string
+		expectedSources map[string]clif.FlagSource
+		expectedParsed  map[string]clif.Flag
 		expectedErr     error
 	}
 
@@ -36,6 +40,406 @@ func TestRoute(t *testing.T) {
 				"name": flagtypes.ListFlag[string]{Name: "name", RawValue: "foo, bar, baaz", Value: []string{"foo", "bar", "baaz"}},
 			},
 		},
+		"short-flags": {
+			input:           []string{"hello", "-n", "foo", "-v"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.BasicFlag[bool]{Name: "verbose", Value: true},
+				"name":    flagtypes.BasicFlag[string]{Name: "name", RawValue: "foo", Value: "foo"},
+			},
+		},
+		"short-flags-bundled": {
+			input:           []string{"hello", "-vfnfoo"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.BasicFlag[bool]{Name: "verbose", Value: true},
+				"force":   flagtypes.BasicFlag[bool]{Name: "force", Value: true},
+				"name":    flagtypes.BasicFlag[string]{Name: "name", RawValue: "foo", Value: "foo"},
+			},
+		},
+		"short-flags-attached-value": {
+			input:           []string{"-c5", "hello", "-n=foo"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"count": flagtypes.BasicFlag[int64]{Name: "count", RawValue: "5", Value: 5},
+				"name":  flagtypes.BasicFlag[string]{Name: "name", RawValue: "foo", Value: "foo"},
+			},
+		},
+		"short-flags-bundled-value": {
+			input:           []string{"hello", "-vn", "foo", "bar"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.BasicFlag[bool]{Name: "verbose", Value: true},
+				"name":    flagtypes.BasicFlag[string]{Name: "name", RawValue: "foo", Value: "foo"},
+			},
+			expectedArgs: []string{"bar"},
+		},
+		"short-flags-unknown-in-bundle": {
+			input:       []string{"hello", "-vx"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.UnknownFlagNameError("x"),
+		},
+		"short-flags-unexpected-value": {
+			input:       []string{"hello", "-v=false"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.UnexpectedFlagValueError{Flag: "v", Value: "false"},
+		},
+		"short-flags-missing-value": {
+			input:       []string{"hello", "-n"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.MissingFlagValueError("name"),
+		},
+		"short-flags-bundled-missing-value": {
+			input:       []string{"hello", "-vn"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.MissingFlagValueError("name"),
+		},
+		"long-flag-missing-value": {
+			input:       []string{"hello", "--name"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.MissingFlagValueError("name"),
+		},
+		"flag-value-after-full-args": {
+			input:           []string{"scale", "api", "3", "--region", "us"},
+			app:             argsTestApp(),
+			expectedCmdName: "scale",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "us", Value: "us"},
+			},
+			expectedArgs: []string{"api", "3"},
+		},
+		"flag-value-last-with-room-for-args": {
+			input:       []string{"scale", "api", "--region", "us"},
+			app:         argsTestApp(),
+			expectedErr: clif.MissingFlagValueError("region"),
+		},
+		"short-flags-unknown-is-arg": {
+			input:           []string{"hello", "-5"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags:   map[string]clif.Flag{},
+			expectedArgs:    []string{"-5"},
+		},
+		"terminator": {
+			input:           []string{"hello", "-v", "--", "--name", "foo", "hello", "-v"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.BasicFlag[bool]{Name: "verbose", Value: true},
+			},
+			expectedArgs: []string{"--name", "foo", "hello", "-v"},
+		},
+		"terminator-closes-open-flag": {
+			input:           []string{"hello", "--name", "--", "bar"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"name": flagtypes.BasicFlag[string]{Name: "name"},
+			},
+			expectedArgs: []string{"bar"},
+		},
+		"terminator-nothing-after": {
+			input:           []string{"hello", "--"},
+			app:             shortFlagsTestApp(),
+			expectedCmdName: "hello",
+			expectedFlags:   map[string]clif.Flag{},
+		},
+		"terminator-args-not-accepted": {
+			input:       []string{"--", "hello"},
+			app:         shortFlagsTestApp(),
+			expectedErr: clif.UnexpectedCommandArgError("hello"),
+		},
+		"env": {
+			input:           []string{"deploy", "--force"},
+			app:             envTestApp(map[string]string{"DEPLOY_REGION": "us-east-1", "FORCE": "false", "TIMEOUT": "5"}),
+			expectedCmdName: "deploy",
+			expectedFlags: map[string]clif.Flag{
+				"region":  flagtypes.BasicFlag[string]{Name: "region", RawValue: "us-east-1", Value: "us-east-1"},
+				"force":   flagtypes.BasicFlag[bool]{Name: "force", Value: true},
+				"timeout": flagtypes.BasicFlag[int64]{Name: "timeout", RawValue: "5", Value: 5},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"region":  clif.FlagSourceEnv,
+				"force":   clif.FlagSourceArgs,
+				"timeout": clif.FlagSourceEnv,
+			},
+		},
+		"env-fallback-order": {
+			input:           []string{"deploy"},
+			app:             envTestApp(map[string]string{"DEPLOY_REGION": "us-east-1", "REGION": "eu-west-1"}),
+			expectedCmdName: "deploy",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "eu-west-1", Value: "eu-west-1"},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"region": clif.FlagSourceEnv,
+			},
+		},
+		"env-args-take-precedence": {
+			input:           []string{"--timeout=10", "deploy", "--region", "ap-south-1"},
+			app:             envTestApp(map[string]string{"REGION": "eu-west-1", "TIMEOUT": "5"}),
+			expectedCmdName: "deploy",
+			expectedFlags: map[string]clif.Flag{
+				"region":  flagtypes.BasicFlag[string]{Name: "region", RawValue: "ap-south-1", Value: "ap-south-1"},
+				"timeout": flagtypes.BasicFlag[int64]{Name: "timeout", RawValue: "10", Value: 10},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"region":  clif.FlagSourceArgs,
+				"timeout": clif.FlagSourceArgs,
+			},
+		},
+		"config": {
+			input: []string{"deploy"},
+			app: withConfig(envTestApp(map[string]string{"REGION": "eu-west-1"}), clif.Config{
+				"":       {"timeout": {"5"}, "region": {"ap-south-1"}},
+				"deploy": {"region": {"us-east-1"}, "force": {"true"}},
+			}),
+			expectedCmdName: "deploy",
+			expectedFlags: map[string]clif.Flag{
+				"region":  flagtypes.BasicFlag[string]{Name: "region", RawValue: "eu-west-1", Value: "eu-west-1"},
+				"force":   flagtypes.BasicFlag[bool]{Name: "force", RawValue: "true", Value: true},
+				"timeout": flagtypes.BasicFlag[int64]{Name: "timeout", RawValue: "5", Value: 5},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"region":  clif.FlagSourceEnv,
+				"force":   clif.FlagSourceConfig,
+				"timeout": clif.FlagSourceConfig,
+			},
+		},
+		"config-scopes": {
+			input: []string{"deploy", "--force"},
+			app: withConfig(envTestApp(nil), clif.Config{
+				"":       {"region": {"ap-south-1"}, "force": {"false"}},
+				"deploy": {"region": {"us-east-1"}},
+			}),
+			expectedCmdName: "deploy",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "us-east-1", Value: "us-east-1"},
+				"force":  flagtypes.BasicFlag[bool]{Name: "force", Value: true},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"region": clif.FlagSourceConfig,
+				"force":  clif.FlagSourceArgs,
+			},
+		},
+		"config-list": {
+			input: []string{"hello"},
+			app: clif.Application{
+				Commands: []clif.Command{{Name: "hello", Flags: []clif.FlagDef{{Name: "name", ValueAccepted: true, Parser: flagtypes.StringListParser{}}}}},
+				Config:   clif.Config{"hello": {"name": {"foo", "bar"}}},
+			},
+			expectedCmdName: "hello",
+			expectedFlags: map[string]clif.Flag{
+				"name": flagtypes.ListFlag[string]{Name: "name", RawValue: "foo, bar", Value: []string{"foo", "bar"}},
+			},
+		},
+		"config-invalid": {
+			input:       []string{"deploy"},
+			app:         withConfig(envTestApp(nil), clif.Config{"": {"timeout": {"soon"}}}),
+			expectedErr: strconv.ErrSyntax,
+		},
+		"defaults": {
+			input: []string{"wait", "--attempts", "3"},
+			app: clif.Application{
+				Commands: []clif.Command{{Name: "wait", Flags: []clif.FlagDef{
+					{Name: "timeout", ValueAccepted: true, Default: "30s", Parser: flagtypes.DurationParser{}},
+					{Name: "attempts", ValueAccepted: true, Default: "1", Parser: flagtypes.IntParser{}},
+					{Name: "interval", ValueAccepted: true, Default: "1s", EnvVars: []string{"INTERVAL"}, Parser: flagtypes.DurationParser{}},
+				}}},
+				LookupEnv: func(key string) (string, bool) {
+					return map[string]string{"INTERVAL": "5s"}[key], key == "INTERVAL"
+				},
+			},
+			expectedCmdName: "wait",
+			expectedFlags: map[string]clif.Flag{
+				"timeout":  flagtypes.BasicFlag[time.Duration]{Name: "timeout", RawValue: "30s", Value: 30 * time.Second},
+				"attempts": flagtypes.BasicFlag[int64]{Name: "attempts", RawValue: "3", Value: 3},
+				"interval": flagtypes.BasicFlag[time.Duration]{Name: "interval", RawValue: "5s", Value: 5 * time.Second},
+			},
+			expectedSources: map[string]clif.FlagSource{
+				"timeout":  clif.FlagSourceDefault,
+				"attempts": clif.FlagSourceArgs,
+				"interval": clif.FlagSourceEnv,
+			},
+		},
+		"required-flag-missing": {
+			input:       []string{"scale", "api"},
+			app:         argsTestApp(),
+			expectedErr: clif.MissingRequiredFlagError{CommandPath: argsTestApp().Commands[:1], Flag: "region"},
+		},
+		"required-flag-from-default": {
+			input: []string{"scale", "api"},
+			app: func() clif.Application {
+				app := argsTestApp()
+				app.Commands[0].Flags[0].Default = "us-east-1"
+				return app
+			}(),
+			expectedCmdName: "scale",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "us-east-1", Value: "us-east-1"},
+			},
+			expectedArgs: []string{"api"},
+		},
+		"args-optional": {
+			input:           []string{"scale", "--region", "us-east-1", "api", "3"},
+			app:             argsTestApp(),
+			expectedCmdName: "scale",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "us-east-1", Value: "us-east-1"},
+			},
+			expectedArgs: []string{"api", "3"},
+		},
+		"args-too-few": {
+			input:       []string{"scale", "--region=us-east-1"},
+			app:         argsTestApp(),
+			expectedErr: clif.ArgCountError{CommandPath: argsTestApp().Commands[:1], Min: 1, Max: 2, Got: 0},
+		},
+		"args-too-many": {
+			input:       []string{"scale", "--region", "us-east-1", "api", "3", "4"},
+			app:         argsTestApp(),
+			expectedErr: clif.ArgCountError{CommandPath: argsTestApp().Commands[:1], Min: 1, Max: 2, Got: 3},
+		},
+		"args-variadic": {
+			input:           []string{"logs", "api", "web", "db"},
+			app:             argsTestApp(),
+			expectedCmdName: "logs",
+			expectedFlags:   map[string]clif.Flag{},
+			expectedArgs:    []string{"api", "web", "db"},
+		},
+		"args-variadic-too-few": {
+			input:       []string{"logs"},
+			app:         argsTestApp(),
+			expectedErr: clif.ArgCountError{CommandPath: argsTestApp().Commands[1:], Min: 1, Max: -1, Got: 0},
+		},
+		"args-parsed": {
+			input:           []string{"resize", "api", "3"},
+			app:             typedArgsTestApp(),
+			expectedCmdName: "resize",
+			expectedFlags:   map[string]clif.Flag{},
+			expectedArgs:    []string{"api", "3"},
+			expectedParsed: map[string]clif.Flag{
+				"service":  flagtypes.BasicFlag[string]{Name: "service", RawValue: "api", Value: "api"},
+				"replicas": flagtypes.BasicFlag[int64]{Name: "replicas", RawValue: "3", Value: 3},
+			},
+		},
+		"args-parsed-optional-missing": {
+			input:           []string{"resize", "api"},
+			app:             typedArgsTestApp(),
+			expectedCmdName: "resize",
+			expectedFlags:   map[string]clif.Flag{},
+			expectedArgs:    []string{"api"},
+			expectedParsed: map[string]clif.Flag{
+				"service": flagtypes.BasicFlag[string]{Name: "service", RawValue: "api", Value: "api"},
+			},
+		},
+		"args-parsed-variadic": {
+			input:           []string{"sleep", "api", "1s", "5m"},
+			app:             typedArgsTestApp(),
+			expectedCmdName: "sleep",
+			expectedFlags:   map[string]clif.Flag{},
+			expectedArgs:    []string{"api", "1s", "5m"},
+			expectedParsed: map[string]clif.Flag{
+				"durations": flagtypes.ListFlag[time.Duration]{Name: "durations", RawValue: "1s, 5m0s", Value: []time.Duration{time.Second, 5 * time.Minute}},
+			},
+		},
+		"args-parsed-invalid": {
+			input:       []string{"resize", "api", "lots"},
+			app:         typedArgsTestApp(),
+			expectedErr: strconv.ErrSyntax,
+		},
+		"env-invalid": {
+			input:       []string{"deploy"},
+			app:         envTestApp(map[string]string{"TIMEOUT": "soon"}),
+			expectedErr: strconv.ErrSyntax,
+		},
+		"suggest-subcommand": {
+			input:       []string{"deplyo"},
+			app:         completionTestApp(),
+			expectedErr: clif.SuggestionError{Err: clif.UnexpectedCommandArgError("deplyo"), Suggestions: []string{"deploy"}},
+		},
+		"suggest-subcommand-prefix": {
+			input:       []string{"de"},
+			app:         completionTestApp(),
+			expectedErr: clif.SuggestionError{Err: clif.UnexpectedCommandArgError("de"), Suggestions: []string{"deploy", "describe"}},
+		},
+		"suggest-subcommand-hidden": {
+			input:       []string{"debg"},
+			app:         completionTestApp(),
+			expectedErr: clif.UnexpectedCommandArgError("debg"),
+		},
+		"prefix": {
+			input:           []string{"dep", "--verb", "stat"},
+			app:             prefixTestApp(),
+			expectedCmdName: "status",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.BasicFlag[bool]{Name: "verbose", Value: true},
+			},
+		},
+		"prefix-exact-alias": {
+			input:           []string{"d", "rollback"},
+			app:             prefixTestApp(),
+			expectedCmdName: "rollback",
+			expectedFlags:   map[string]clif.Flag{},
+		},
+		"prefix-ambiguous": {
+			input:       []string{"de"},
+			app:         prefixTestApp(),
+			expectedErr: clif.AmbiguousPrefixError{Prefix: "de", Candidates: []string{"deploy", "describe"}},
+		},
+		"prefix-hidden": {
+			input:       []string{"deb"},
+			app:         prefixTestApp(),
+			expectedErr: clif.UnexpectedCommandArgError("deb"),
+		},
+		"prefix-empty-subcommand": {
+			input:       []string{""},
+			app:         prefixTestApp(),
+			expectedErr: clif.UnexpectedCommandArgError(""),
+		},
+		"prefix-empty-only-subcommand": {
+			input: []string{""},
+			app: clif.Application{
+				PrefixMatching: true,
+				Commands:       []clif.Command{{Name: "deploy"}},
+			},
+			expectedErr: clif.UnexpectedCommandArgError(""),
+		},
+		"prefix-empty-flag": {
+			input:       []string{"deploy", "--=x"},
+			app:         prefixTestApp(),
+			expectedErr: clif.UnknownFlagNameError(""),
+		},
+		"prefix-flag-value": {
+			input:           []string{"deploy", "--reg", "st", "status"},
+			app:             prefixTestApp(),
+			expectedCmdName: "status",
+			expectedFlags: map[string]clif.Flag{
+				"region": flagtypes.BasicFlag[string]{Name: "region", RawValue: "st", Value: "st"},
+			},
+		},
+		"persistent-flags": {
+			input:           []string{"deploy", "-v", "rollback", "--region", "us", "--verbose"},
+			app:             persistentTestApp(),
+			expectedCmdName: "rollback",
+			expectedFlags: map[string]clif.Flag{
+				"verbose": flagtypes.ListFlag[bool]{Name: "verbose", RawValue: "true, true", Value: []bool{true, true}},
+				"region":  flagtypes.BasicFlag[string]{Name: "region", RawValue: "us", Value: "us"},
+			},
+		},
+		"persistent-flags-not-inherited": {
+			input:       []string{"deploy", "--config", "foo"},
+			app:         persistentTestApp(),
+			expectedErr: clif.UnknownFlagNameError("config"),
+		},
+		"suggest-flag": {
+			input:       []string{"deploy", "--verbsoe"},
+			app:         completionTestApp(),
+			expectedErr: clif.SuggestionError{Err: clif.UnknownFlagNameError("verbsoe"), Suggestions: []string{"--verbose"}},
+		},
 	}
 	for name, testCase := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -47,7 +451,7 @@ func TestRoute(t *testing.T) {
 			if err == nil && testCase.expectedErr != nil {
 				t.Fatal("Expected error, didn't get one")
 			}
-			if err != nil && testCase.expectedErr != nil && !errors.Is(err, testCase.expectedErr) {
+			if err != nil && testCase.expectedErr != nil && !errors.Is(err, testCase.expectedErr) && !cmp.Equal(err, testCase.expectedErr) {
 				t.Fatalf("Expected error %+v, got error %+v", testCase.expectedErr, err)
 			}
 			if err != nil && testCase.expectedErr != nil {
@@ -62,6 +466,131 @@ func TestRoute(t *testing.T) {
 			if diff := cmp.Diff(testCase.expectedArgs, res.Args); diff != "" {
 				t.Errorf("Unexpected diff comparing args (-expected, +got): %s", diff)
 			}
+			if testCase.expectedParsed != nil {
+				if diff := cmp.Diff(testCase.expectedParsed, res.ParsedArgs); diff != "" {
+					t.Errorf("Unexpected diff comparing parsed args (-expected, +got): %s", diff)
+				}
+			}
+			if testCase.expectedSources != nil {
+				if diff := cmp.Diff(testCase.expectedSources, res.Sources); diff != "" {
+					t.Errorf("Unexpected diff comparing sources (-expected, +got): %s", diff)
+				}
+			}
 		})
 	}
 }
+
+func shortFlagsTestApp() clif.Application {
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name:         "hello",
+				ArgsAccepted: true,
+				Flags: []clif.FlagDef{
+					{Name: "verbose", Short: 'v', Parser: flagtypes.BoolParser{}},
+					{Name: "force", Short: 'f', Parser: flagtypes.BoolParser{}},
+					{Name: "name", Short: 'n', ValueAccepted: true, Parser: flagtypes.StringParser{}},
+				},
+			},
+		},
+		Flags: []clif.FlagDef{
+			{Name: "count", Short: 'c', ValueAccepted: true, Parser: flagtypes.IntParser{}},
+		},
+	}
+}
+
+func persistentTestApp() clif.Application {
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "deploy",
+				Flags: []clif.FlagDef{
+					{Name: "region", ValueAccepted: true, Persistent: true, Parser: flagtypes.StringParser{}},
+				},
+				Subcommands: []clif.Command{
+					{Name: "rollback"},
+				},
+			},
+		},
+		Flags: []clif.FlagDef{
+			{Name: "verbose", Short: 'v', Persistent: true, Parser: flagtypes.BoolListParser{}},
+			{Name: "config", ValueAccepted: true, Parser: flagtypes.StringParser{}},
+		},
+	}
+}
+
+func prefixTestApp() clif.Application {
+	app := completionTestApp()
+	app.PrefixMatching = true
+	return app
+}
+
+func envTestApp(env map[string]string) clif.Application {
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "deploy",
+				Flags: []clif.FlagDef{
+					{Name: "region", ValueAccepted: true, EnvVars: []string{"REGION", "DEPLOY_REGION"}, Parser: flagtypes.StringParser{}},
+					{Name: "force", EnvVars: []string{"FORCE"}, Parser: flagtypes.BoolParser{}},
+				},
+			},
+		},
+		Flags: []clif.FlagDef{
+			{Name: "timeout", ValueAccepted: true, EnvVars: []string{"TIMEOUT"}, Parser: flagtypes.IntParser{}},
+		},
+		LookupEnv: func(key string) (string, bool) {
+			val, ok := env[key]
+			return val, ok
+		},
+	}
+}
+
+func withConfig(app clif.Application, config clif.Config) clif.Application {
+	app.Config = config
+	return app
+}
+
+func argsTestApp() clif.Application {
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "scale",
+				Flags: []clif.FlagDef{
+					{Name: "region", ValueAccepted: true, Required: true, Parser: flagtypes.StringParser{}},
+				},
+				Args: []clif.ArgDef{
+					{Name: "service"},
+					{Name: "replicas", Optional: true},
+				},
+			},
+			{
+				Name: "logs",
+				Args: []clif.ArgDef{
+					{Name: "services", Variadic: true},
+				},
+			},
+		},
+	}
+}
+
+func typedArgsTestApp() clif.Application {
+	return clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "resize",
+				Args: []clif.ArgDef{
+					{Name: "service", Parser: flagtypes.StringParser{}},
+					{Name: "replicas", Optional: true, Parser: flagtypes.IntParser{}},
+				},
+			},
+			{
+				Name: "sleep",
+				Args: []clif.ArgDef{
+					{Name: "service"},
+					{Name: "durations", Variadic: true, Parser: flagtypes.DurationListParser{}},
+				},
+			},
+		},
+	}
+}
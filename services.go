@@ -0,0 +1,153 @@
+package clif
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// MissingServiceError is returned by [Get] when no service of the requested
+// type was provided with [Provide].
+type MissingServiceError struct {
+	// Type is the type of service that was requested.
+	Type reflect.Type
+}
+
+func (err MissingServiceError) Error() string {
+	return fmt.Sprintf("no service of type %s provided", err.Type)
+}
+
+// serviceConstructor builds a service, returning the service, a function to
+// clean it up, and any error encountered building it.
+type serviceConstructor func(ctx context.Context) (any, func(), error)
+
+// Provide is a [RunOption] that registers a constructor for services of type
+// T, which can then be retrieved with [Get] by the PreRunHooks,
+// HandlerBuilders, and Handlers of the application. The constructor is only
+// called the first time a service of type T is requested during a run, and
+// the same service is returned for the rest of the run. The cleanup function
+// the constructor returns, if it's not nil, is called after the [Handler] and
+// any PostRunHooks are done, with cleanup functions for services that were
+// constructed later being called first.
+//
+// Constructors can use [Get] to request other services they depend on, but
+// services must not depend on themselves, directly or indirectly.
+func Provide[T any](constructor func(ctx context.Context) (T, func(), error)) RunOption {
+	return func(opts *RunOptions) {
+		if opts.services == nil {
+			opts.services = map[reflect.Type]serviceConstructor{}
+		}
+		opts.services[reflect.TypeFor[T]()] = func(ctx context.Context) (any, func(), error) {
+			return constructor(ctx)
+		}
+	}
+}
+
+// Get returns the service of type T registered with [Provide] for the current
+// run, constructing it if it hasn't been constructed yet. If no service of
+// type T was provided, a [MissingServiceError] is returned. If the
+// constructor returns an error, that error is returned every time the service
+// is requested during the run.
+func Get[T any](ctx context.Context) (T, error) {
+	var zero T
+	typ := reflect.TypeFor[T]()
+	container, ok := ctx.Value(servicesContextKey{}).(*services)
+	if !ok {
+		return zero, MissingServiceError{Type: typ}
+	}
+	value, err := container.get(ctx, typ)
+	if err != nil {
+		return zero, err
+	}
+	service, ok := value.(T)
+	if !ok {
+		return zero, MissingServiceError{Type: typ}
+	}
+	return service, nil
+}
+
+type servicesContextKey struct{}
+
+// service holds a lazily-constructed service.
+type service struct {
+	once        sync.Once
+	constructor serviceConstructor
+	value       any
+	err         error
+}
+
+// services holds the services available during a single run.
+type services struct {
+	byType map[reflect.Type]*service
+
+	mu       sync.Mutex
+	cleanups []func()
+}
+
+// newServices returns a container for the services the passed constructors
+// build.
+func newServices(constructors map[reflect.Type]serviceConstructor) *services {
+	container := &services{byType: make(map[reflect.Type]*service, len(constructors))}
+	for typ, constructor := range constructors {
+		container.byType[typ] = &service{constructor: constructor}
+	}
+	return container
+}
+
+// get returns the service of the passed type, constructing it if necessary.
+func (container *services) get(ctx context.Context, typ reflect.Type) (any, error) {
+	svc, ok := container.byType[typ]
+	if !ok {
+		return nil, MissingServiceError{Type: typ}
+	}
+	svc.once.Do(func() {
+		var cleanup func()
+		svc.value, cleanup, svc.err = svc.constructor(ctx)
+		if cleanup != nil {
+			container.addCleanup(cleanup)
+		}
+	})
+	return svc.value, svc.err
+}
+
+// addCleanup registers a function to be called when the run is over.
+func (container *services) addCleanup(cleanup func()) {
+	container.mu.Lock()
+	defer container.mu.Unlock()
+	container.cleanups = append(container.cleanups, cleanup)
+}
+
+// cleanup calls the registered cleanup functions, most recently registered
+// first.
+func (container *services) cleanup() {
+	container.mu.Lock()
+	cleanups := container.cleanups
+	container.cleanups = nil
+	container.mu.Unlock()
+	for pos := len(cleanups) - 1; pos >= 0; pos-- {
+		cleanups[pos]()
+	}
+}
+
+// cleanupWithin calls the registered cleanup functions like cleanup, but
+// stops waiting for them after the passed timeout. A timeout of 0 or less
+// waits as long as they take.
+func (container *services) cleanupWithin(timeout time.Duration) {
+	if timeout <= 0 {
+		container.cleanup()
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		container.cleanup()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	}
+}
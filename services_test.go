@@ -0,0 +1,106 @@
+package clif_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"impractical.co/clif"
+)
+
+var errDatabaseDown = errors.New("database is down")
+
+type clock interface {
+	Now() time.Time
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+type database struct {
+	name  string
+	clock clock
+}
+
+func ExampleProvide() {
+	app := clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "report",
+				Handler: funcCommandHandler(func(ctx context.Context, resp *clif.Response) {
+					db, err := clif.Get[*database](ctx)
+					if err != nil {
+						resp.Err = err
+						return
+					}
+					fmt.Fprintln(resp.Output, "reporting from", db.name, "at", db.clock.Now().Format(time.DateOnly)) //nolint:errcheck // if there's an error, we can't do anything
+				}),
+			},
+		},
+	}
+	code := app.Run(context.Background(),
+		clif.WithArgs([]string{"report"}),
+		clif.Provide(func(_ context.Context) (clock, func(), error) {
+			fmt.Println("constructing clock")
+			return fixedClock(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)), func() { fmt.Println("cleaning up clock") }, nil
+		}),
+		clif.Provide(func(ctx context.Context) (*database, func(), error) {
+			fmt.Println("constructing database")
+			clk, err := clif.Get[clock](ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &database{name: "reports", clock: clk}, func() { fmt.Println("cleaning up database") }, nil
+		}),
+	)
+	fmt.Println(code)
+	// output:
+	// constructing database
+	// constructing clock
+	// reporting from reports at 2024-03-01
+	// cleaning up database
+	// cleaning up clock
+	// 0
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	var calls int
+	var errs []error
+	handler := funcCommandHandler(func(ctx context.Context, _ *clif.Response) {
+		_, err := clif.Get[clock](ctx)
+		errs = append(errs, err)
+		_, err = clif.Get[*database](ctx)
+		errs = append(errs, err)
+		_, err = clif.Get[*database](ctx)
+		errs = append(errs, err)
+	})
+	app := clif.Application{Commands: []clif.Command{{Name: "report", Handler: handler}}}
+	app.Run(context.Background(), clif.WithArgs([]string{"report"}), clif.Provide(func(_ context.Context) (*database, func(), error) {
+		calls++
+		return nil, nil, errDatabaseDown
+	}))
+	if len(errs) != 3 {
+		t.Fatalf("Expected 3 errors, got %d", len(errs))
+	}
+	if !errors.Is(errs[0], clif.MissingServiceError{Type: reflect.TypeFor[clock]()}) {
+		t.Errorf("Expected MissingServiceError, got %v", errs[0])
+	}
+	for _, err := range errs[1:] {
+		if !errors.Is(err, errDatabaseDown) {
+			t.Errorf("Expected %v, got %v", errDatabaseDown, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected constructor to be called once, got %d", calls)
+	}
+
+	_, err := clif.Get[clock](context.Background())
+	if !errors.Is(err, clif.MissingServiceError{Type: reflect.TypeFor[clock]()}) {
+		t.Errorf("Expected MissingServiceError outside of Run, got %v", err)
+	}
+}
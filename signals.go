@@ -0,0 +1,99 @@
+package clif
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// signalExitBase is added to the number of the signal that interrupted a
+// command to get its status code, following shell conventions.
+const signalExitBase = 128
+
+// AddCleanup registers a function to be called when the command being run by
+// [Application.Run] is done, after its [Handler] and any PostRunHooks. Cleanup
+// functions are called in the reverse of the order they're registered in,
+// alongside the cleanup functions for services registered with [Provide]. If
+// signal handling is enabled with [WithSignalHandling], cleanup functions are
+// given its timeout to finish. AddCleanup returns false if the passed context
+// didn't come from [Application.Run], in which case the function will never
+// be called.
+func AddCleanup(ctx context.Context, cleanup func()) bool {
+	container, ok := ctx.Value(servicesContextKey{}).(*services)
+	if !ok {
+		return false
+	}
+	container.addCleanup(cleanup)
+	return true
+}
+
+// signalHandler cancels a context when the process receives SIGINT or SIGTERM,
+// and exits the process if it receives a second one.
+type signalHandler struct {
+	signals chan os.Signal
+	done    chan struct{}
+	cancel  context.CancelFunc
+	exit    func(code int)
+
+	mu       sync.Mutex
+	received os.Signal
+}
+
+// handleSignals starts listening for signals, returning a context.Context
+// that will be canceled when one is received.
+func handleSignals(ctx context.Context, exit func(code int)) (context.Context, *signalHandler) {
+	ctx, cancel := context.WithCancel(ctx)
+	handler := &signalHandler{
+		signals: make(chan os.Signal, 2), //nolint:mnd // we care about two signals
+		done:    make(chan struct{}),
+		cancel:  cancel,
+		exit:    exit,
+	}
+	signal.Notify(handler.signals, os.Interrupt, syscall.SIGTERM)
+	go handler.listen()
+	return ctx, handler
+}
+
+// listen cancels the context on the first signal, and exits on the second.
+func (handler *signalHandler) listen() {
+	select {
+	case sig := <-handler.signals:
+		handler.mu.Lock()
+		handler.received = sig
+		handler.mu.Unlock()
+		handler.cancel()
+	case <-handler.done:
+		return
+	}
+	select {
+	case sig := <-handler.signals:
+		handler.exit(signalExitCode(sig))
+	case <-handler.done:
+	}
+}
+
+// stop stops listening for signals, and returns the status code the command
+// should exit with: the passed code, unless a signal was received.
+func (handler *signalHandler) stop(code int) int {
+	signal.Stop(handler.signals)
+	close(handler.done)
+	handler.cancel()
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if handler.received != nil {
+		return signalExitCode(handler.received)
+	}
+	return code
+}
+
+// signalExitCode returns the conventional status code for a command that was
+// interrupted by the passed signal, like 130 for SIGINT and 143 for SIGTERM.
+func signalExitCode(sig os.Signal) int {
+	number, ok := sig.(syscall.Signal)
+	if !ok {
+		return ExitFailure
+	}
+	return signalExitBase + int(number)
+}
@@ -0,0 +1,73 @@
+//go:build unix
+
+package clif
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+//nolint:paralleltest // sends signals to the test process
+func TestSecondSignalExits(t *testing.T) {
+	type testCase struct {
+		signal       syscall.Signal
+		expectedCode int
+	}
+
+	cases := map[string]testCase{
+		"interrupt": {
+			signal:       syscall.SIGINT,
+			expectedCode: 130,
+		},
+		"terminate": {
+			signal:       syscall.SIGTERM,
+			expectedCode: 143,
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			exited := make(chan int, 1)
+			code := -1
+			handler := HandlerFunc(func(ctx context.Context, _ *Response) {
+				err := syscall.Kill(syscall.Getpid(), testCase.signal)
+				if err != nil {
+					t.Errorf("Error sending signal: %v", err)
+					return
+				}
+				<-ctx.Done()
+				err = syscall.Kill(syscall.Getpid(), testCase.signal)
+				if err != nil {
+					t.Errorf("Error sending signal: %v", err)
+					return
+				}
+				// exit would normally end the process, so the
+				// command never gets this far
+				select {
+				case code = <-exited:
+				case <-time.After(5 * time.Second):
+					t.Error("Expected exit to be called after the second signal")
+				}
+			})
+			app := Application{
+				Commands: []Command{
+					{
+						Name: "watch",
+						Handler: HandlerBuilderFunc(func(_ context.Context, _ map[string]Flag, _ []string, _ *Response) Handler {
+							return handler
+						}),
+					},
+				},
+			}
+			app.Run(context.Background(), WithArgs([]string{"watch"}), WithSignalHandling(time.Second), func(opts *RunOptions) {
+				opts.exit = func(code int) {
+					exited <- code
+				}
+			})
+			if code != testCase.expectedCode {
+				t.Errorf("Expected exit to be called with %d, got %d", testCase.expectedCode, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//go:build unix
+
+package clif_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"impractical.co/clif"
+)
+
+//nolint:paralleltest // sends signals to the test process
+func TestWithSignalHandling(t *testing.T) {
+	type testCase struct {
+		signal       syscall.Signal
+		blockCleanup bool
+		expectedCode int
+		expectedDone bool
+	}
+
+	cases := map[string]testCase{
+		"interrupt": {
+			signal:       syscall.SIGINT,
+			expectedCode: 130,
+			expectedDone: true,
+		},
+		"terminate": {
+			signal:       syscall.SIGTERM,
+			expectedCode: 143,
+			expectedDone: true,
+		},
+		"cleanup-timeout": {
+			signal:       syscall.SIGINT,
+			blockCleanup: true,
+			expectedCode: 130,
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			cleanupDone := make(chan struct{})
+			// closed when the test is done, so a blocked cleanup
+			// function doesn't outlive it
+			release := make(chan struct{})
+			defer close(release)
+			app := clif.Application{
+				Commands: []clif.Command{
+					{
+						Name: "watch",
+						Handler: funcCommandHandler(func(ctx context.Context, _ *clif.Response) {
+							clif.AddCleanup(ctx, func() {
+								if testCase.blockCleanup {
+									<-release
+								}
+								close(cleanupDone)
+							})
+							err := syscall.Kill(syscall.Getpid(), testCase.signal)
+							if err != nil {
+								t.Errorf("Error sending signal: %v", err)
+								return
+							}
+							select {
+							case <-ctx.Done():
+							case <-time.After(5 * time.Second):
+								t.Error("Context wasn't canceled after signal")
+							}
+						}),
+					},
+				},
+			}
+			start := time.Now()
+			code := app.Run(context.Background(), clif.WithArgs([]string{"watch"}), clif.WithSignalHandling(100*time.Millisecond))
+			if code != testCase.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedCode, code)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("Expected Run to return within the shutdown timeout, took %s", elapsed)
+			}
+			select {
+			case <-cleanupDone:
+				if !testCase.expectedDone {
+					t.Error("Expected cleanup to still be running")
+				}
+			default:
+				if testCase.expectedDone {
+					t.Error("Expected cleanup to have run")
+				}
+			}
+		})
+	}
+}
+
+func TestAddCleanupOutsideRun(t *testing.T) {
+	t.Parallel()
+	if clif.AddCleanup(context.Background(), func() {}) {
+		t.Error("Expected AddCleanup to fail outside of Run")
+	}
+}
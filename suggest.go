@@ -0,0 +1,134 @@
+package clif
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxSuggestionDistance is the largest edit distance between the input and a
+// name for the name to be suggested.
+const maxSuggestionDistance = 2
+
+// SuggestionError wraps an error caused by mistyped input with the names the
+// user may have meant to type instead.
+type SuggestionError struct {
+	// Err is the error caused by the mistyped input.
+	Err error
+
+	// Suggestions are the names the user may have meant, closest match
+	// first.
+	Suggestions []string
+}
+
+func (err SuggestionError) Error() string {
+	quoted := make([]string, 0, len(err.Suggestions))
+	for _, suggestion := range err.Suggestions {
+		quoted = append(quoted, strconv.Quote(suggestion))
+	}
+	return err.Err.Error() + ", did you mean " + strings.Join(quoted, " or ") + "?"
+}
+
+func (err SuggestionError) Unwrap() error {
+	return err.Err
+}
+
+// withSuggestions wraps the passed error in a [SuggestionError] if any of the
+// passed candidates are close enough to input to be suggested. Each candidate
+// is a group of equivalent names, like a name followed by its aliases. If any
+// name in a group is close enough, the first name in the group is suggested.
+func withSuggestions(err error, input string, candidates [][]string) error {
+	suggestions := suggest(input, candidates)
+	if len(suggestions) < 1 {
+		return err
+	}
+	return SuggestionError{Err: err, Suggestions: suggestions}
+}
+
+// suggest returns the first name of each of the passed groups of names that
+// input could be a typo or abbreviation of a name in, sorted by how close
+// they are.
+func suggest(input string, candidates [][]string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+	input = strings.ToLower(input)
+	var matches []match
+	for _, group := range candidates {
+		best := match{distance: -1}
+		for _, name := range group {
+			lower := strings.ToLower(name)
+			distance := levenshtein(input, lower)
+			near := distance <= maxSuggestionDistance && distance < len(input)
+			prefix := len(input) > 1 && strings.HasPrefix(lower, input)
+			if !near && !prefix {
+				continue
+			}
+			if best.distance < 0 || distance < best.distance {
+				best = match{name: group[0], distance: distance}
+			}
+		}
+		if best.distance >= 0 {
+			matches = append(matches, best)
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return a.distance - b.distance
+	})
+	results := make([]string, 0, len(matches))
+	for _, match := range matches {
+		results = append(results, match.name)
+	}
+	return results
+}
+
+// levenshtein returns the number of single character insertions, deletions,
+// and substitutions it takes to turn a into b.
+func levenshtein(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	prev := make([]int, len(target)+1)
+	curr := make([]int, len(target)+1)
+	for pos := range prev {
+		prev[pos] = pos
+	}
+	for i := range source {
+		curr[0] = i + 1
+		for j := range target {
+			cost := 1
+			if source[i] == target[j] {
+				cost = 0
+			}
+			curr[j+1] = min(prev[j+1]+1, curr[j]+1, prev[j]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(target)]
+}
+
+// subcommandNames returns the names and aliases of the subcommands of the
+// passed [Command] or [Application] that aren't hidden, grouped by subcommand.
+func subcommandNames(command parseable) [][]string {
+	var names [][]string
+	for _, sub := range command.subcommands() {
+		if sub.Hidden {
+			continue
+		}
+		names = append(names, append([]string{sub.Name}, sub.Aliases...))
+	}
+	return names
+}
+
+// flagNames returns the names and aliases of the passed FlagDefs, with
+// leading --, grouped by FlagDef.
+func flagNames(flags []FlagDef) [][]string {
+	names := make([][]string, 0, len(flags))
+	for _, flag := range flags {
+		group := []string{"--" + flag.Name}
+		for _, alias := range flag.Aliases {
+			group = append(group, "--"+alias)
+		}
+		names = append(names, group)
+	}
+	return names
+}
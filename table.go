@@ -0,0 +1,366 @@
+package clif
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// columnsFlagName is the name of the flag added by [WithTableFlags]
+	// that selects the columns of a [Table].
+	columnsFlagName = "columns"
+
+	// sortByFlagName is the name of the flag added by [WithTableFlags]
+	// that sorts the rows of a [Table].
+	sortByFlagName = "sort-by"
+
+	// noHeadersFlagName is the name of the flag added by [WithTableFlags]
+	// that leaves the header row out of a [Table].
+	noHeadersFlagName = "no-headers"
+
+	// columnGap is the space between the columns of a [Table].
+	columnGap = "  "
+
+	// minColumnWidth is the narrowest a [Table] will shrink a column to
+	// when fitting it in the terminal.
+	minColumnWidth = 4
+
+	// ellipsis marks a [Table] cell that was truncated.
+	ellipsis = "…"
+)
+
+// Alignment controls how the cells of a [Column] are aligned.
+type Alignment int
+
+const (
+	// AlignLeft aligns the cells of a [Column] to the left. It's the
+	// default.
+	AlignLeft Alignment = iota
+
+	// AlignRight aligns the cells of a [Column] to the right, which is
+	// usually what's wanted for numbers.
+	AlignRight
+)
+
+// Column describes a column of a [Table].
+type Column struct {
+	// Name is the name of the column, used as its header and to select
+	// or sort by it.
+	Name string
+
+	// Align controls how the column's cells are aligned.
+	Align Alignment
+}
+
+// TableOptions controls how a [Table] is written. [Application.Run] fills it
+// in from the flags added by [WithTableFlags] and the width of the terminal
+// the output is written to, which the COLUMNS environment variable overrides.
+type TableOptions struct {
+	// Columns are the names of the columns to include, in the order to
+	// include them in. If empty, all columns are included in the order
+	// they're defined in.
+	Columns []string
+
+	// SortBy is the name of the column to sort the rows by. Values that
+	// are all numbers are sorted numerically, and everything else is
+	// sorted alphabetically. A leading - sorts in descending order. If
+	// empty, rows are left in the order they're added in.
+	SortBy string
+
+	// NoHeaders leaves the header row out of the table.
+	NoHeaders bool
+
+	// Width is the number of characters the table should fit in, usually
+	// the width of the terminal. Columns are narrowed, and their cells
+	// truncated, until the table fits. If 0, the table isn't truncated,
+	// which is the default when the output isn't a terminal.
+	Width int
+}
+
+// UnknownColumnError is returned when a [Table] is asked to select or sort by
+// a column it doesn't have. The underlying string is the column name.
+type UnknownColumnError string
+
+func (err UnknownColumnError) Error() string {
+	return fmt.Sprintf("unknown column %q", string(err))
+}
+
+// Table writes data in aligned columns. Create one with [Response.NewTable],
+// add rows to it with AddRow, and write it with Flush.
+type Table struct {
+	output  io.Writer
+	options TableOptions
+	columns []Column
+	rows    [][]string
+}
+
+// NewTable returns a [Table] with the passed columns that will be written to
+// the [Response]'s Output according to its TableOptions.
+func (resp *Response) NewTable(columns ...Column) *Table {
+	return &Table{
+		output:  resp.Output,
+		options: resp.TableOptions,
+		columns: columns,
+	}
+}
+
+// AddRow adds a row to the [Table], with a cell for each column in the order
+// the columns are defined in. Missing cells are left empty, and extra cells
+// are ignored.
+func (table *Table) AddRow(cells ...string) {
+	row := make([]string, len(table.columns))
+	copy(row, cells)
+	table.rows = append(table.rows, row)
+}
+
+// Flush writes the [Table] to its output.
+func (table *Table) Flush() error {
+	indices, err := table.selectColumns()
+	if err != nil {
+		return err
+	}
+	rows := slices.Clone(table.rows)
+	err = table.sortRows(rows)
+	if err != nil {
+		return err
+	}
+	if !table.options.NoHeaders {
+		headers := make([]string, len(table.columns))
+		for pos, column := range table.columns {
+			headers[pos] = column.Name
+		}
+		rows = append([][]string{headers}, rows...)
+	}
+	widths := table.fitWidths(indices, rows)
+	for _, row := range rows {
+		line := make([]string, 0, len(indices))
+		for pos, index := range indices {
+			cell := truncateCell(row[index], widths[pos])
+			padding := strings.Repeat(" ", widths[pos]-utf8.RuneCountInString(cell))
+			switch {
+			case table.columns[index].Align == AlignRight:
+				cell = padding + cell
+			case pos < len(indices)-1:
+				cell += padding
+			}
+			line = append(line, cell)
+		}
+		_, err := fmt.Fprintln(table.output, strings.Join(line, columnGap))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectColumns returns the indices of the columns to include, in the order
+// to include them in.
+func (table *Table) selectColumns() ([]int, error) {
+	if len(table.options.Columns) < 1 {
+		indices := make([]int, len(table.columns))
+		for pos := range table.columns {
+			indices[pos] = pos
+		}
+		return indices, nil
+	}
+	indices := make([]int, 0, len(table.options.Columns))
+	for _, name := range table.options.Columns {
+		index := table.columnIndex(name)
+		if index < 0 {
+			return nil, UnknownColumnError(name)
+		}
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+// columnIndex returns the index of the column with the passed name, or -1 if
+// there isn't one.
+func (table *Table) columnIndex(name string) int {
+	return slices.IndexFunc(table.columns, func(column Column) bool {
+		return strings.EqualFold(column.Name, strings.TrimSpace(name))
+	})
+}
+
+// sortRows sorts the passed rows by the column named in the TableOptions'
+// SortBy.
+func (table *Table) sortRows(rows [][]string) error {
+	if table.options.SortBy == "" {
+		return nil
+	}
+	name, descending := strings.CutPrefix(table.options.SortBy, "-")
+	index := table.columnIndex(name)
+	if index < 0 {
+		return UnknownColumnError(name)
+	}
+	numeric := true
+	for _, row := range rows {
+		if _, err := strconv.ParseFloat(row[index], 64); err != nil {
+			numeric = false
+			break
+		}
+	}
+	slices.SortStableFunc(rows, func(a, b []string) int {
+		result := strings.Compare(a[index], b[index])
+		if numeric {
+			first, _ := strconv.ParseFloat(a[index], 64)
+			second, _ := strconv.ParseFloat(b[index], 64)
+			result = cmp.Compare(first, second)
+		}
+		if descending {
+			return -result
+		}
+		return result
+	})
+	return nil
+}
+
+// fitWidths returns the width of each of the included columns, narrowing the
+// widest columns until the table fits in the TableOptions' Width.
+func (table *Table) fitWidths(indices []int, rows [][]string) []int {
+	widths := make([]int, len(indices))
+	for _, row := range rows {
+		for pos, index := range indices {
+			widths[pos] = max(widths[pos], utf8.RuneCountInString(row[index]))
+		}
+	}
+	if table.options.Width <= 0 {
+		return widths
+	}
+	total := func() int {
+		sum := len(columnGap) * (len(widths) - 1)
+		for _, width := range widths {
+			sum += width
+		}
+		return sum
+	}
+	for total() > table.options.Width {
+		widest := 0
+		for pos, width := range widths {
+			if width > widths[widest] {
+				widest = pos
+			}
+		}
+		if widths[widest] <= minColumnWidth {
+			break
+		}
+		widths[widest]--
+	}
+	return widths
+}
+
+// truncateCell shortens the passed cell to fit in the passed width, marking it
+// with an ellipsis if anything was cut off.
+func truncateCell(cell string, width int) string {
+	if utf8.RuneCountInString(cell) <= width {
+		return cell
+	}
+	runes := []rune(cell)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// tableOptionFlag is the [Flag] for the flags added by [WithTableFlags].
+type tableOptionFlag struct {
+	name     string
+	rawValue string
+}
+
+func (flag tableOptionFlag) GetName() string     { return flag.name }
+func (flag tableOptionFlag) GetRawValue() string { return flag.rawValue }
+
+// tableOptionParser is the [FlagParser] for the flags added by
+// [WithTableFlags]. Values for the list type are joined with commas, and
+// values for the bool type are validated with [strconv.ParseBool].
+type tableOptionParser struct {
+	flagType string
+}
+
+func (parser tableOptionParser) Parse(_ context.Context, name, value string, prior Flag) (Flag, error) { //nolint:ireturn // FlagParser interface requires returning an interface
+	switch parser.flagType {
+	case "bool":
+		if value == "" {
+			value = "true"
+		}
+		_, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+	case "[]string":
+		if prior != nil {
+			value = prior.GetRawValue() + "," + value
+		}
+	}
+	return tableOptionFlag{name: name, rawValue: value}, nil
+}
+
+func (parser tableOptionParser) FlagType() string {
+	return parser.flagType
+}
+
+// withTableFlags returns a copy of the [Application] with the flags that
+// control [TableOptions] added to it, except for the Commands that define a
+// flag one of them would conflict with.
+func (app Application) withTableFlags() Application {
+	flags := []FlagDef{
+		{
+			Name:          columnsFlagName,
+			Description:   "The columns to include in tables, separated by commas.",
+			ValueAccepted: true,
+			Persistent:    true,
+			Parser:        tableOptionParser{flagType: "[]string"},
+		},
+		{
+			Name:          sortByFlagName,
+			Description:   "The column to sort tables by. Prefix it with - to sort in descending order.",
+			ValueAccepted: true,
+			Persistent:    true,
+			Parser:        tableOptionParser{flagType: "string"},
+		},
+		{
+			Name:        noHeadersFlagName,
+			Description: "Leave the header row out of tables.",
+			Persistent:  true,
+			Parser:      tableOptionParser{flagType: "bool"},
+		},
+	}
+	for _, flag := range flags {
+		app = app.withBuiltinFlag(flag)
+	}
+	return app
+}
+
+// setTableOptions sets the [Response]'s TableOptions from the flags added by
+// [WithTableFlags], if they're set, and the width of the terminal, if the
+// [Response]'s Output is a terminal. The COLUMNS environment variable
+// overrides the width of the terminal, but output that isn't written to a
+// terminal is never truncated.
+func (resp *Response) setTableOptions(app Application, flags map[string]Flag) {
+	if width, ok := terminalWidth(resp.Output); ok {
+		resp.TableOptions.Width = width
+		if columns, ok := app.lookupEnv("COLUMNS"); ok {
+			if width, err := strconv.Atoi(columns); err == nil && width > 0 {
+				resp.TableOptions.Width = width
+			}
+		}
+	}
+	if flag, ok := flags[columnsFlagName].(tableOptionFlag); ok {
+		resp.TableOptions.Columns = nil
+		for _, column := range strings.Split(flag.rawValue, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				resp.TableOptions.Columns = append(resp.TableOptions.Columns, column)
+			}
+		}
+	}
+	if flag, ok := flags[sortByFlagName].(tableOptionFlag); ok {
+		resp.TableOptions.SortBy = flag.rawValue
+	}
+	if flag, ok := flags[noHeadersFlagName].(tableOptionFlag); ok {
+		resp.TableOptions.NoHeaders, _ = strconv.ParseBool(flag.rawValue)
+	}
+}
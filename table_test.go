@@ -0,0 +1,196 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+func ExampleResponse_NewTable() {
+	app := clif.Application{
+		LookupEnv: func(string) (string, bool) { return "", false },
+		Commands: []clif.Command{
+			{
+				Name: "nodes",
+				Handler: funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+					table := resp.NewTable(
+						clif.Column{Name: "NAME"},
+						clif.Column{Name: "PODS", Align: clif.AlignRight},
+						clif.Column{Name: "ZONE"},
+					)
+					table.AddRow("node-a", "12", "us-east-1a")
+					table.AddRow("node-b", "7", "us-east-1b")
+					table.AddRow("node-c", "110", "us-east-1a")
+					resp.Err = table.Flush()
+				}),
+			},
+		},
+	}
+	app.Run(context.Background(), clif.WithTableFlags(), clif.WithArgs([]string{"nodes"}))
+	fmt.Println()
+	app.Run(context.Background(), clif.WithTableFlags(), clif.WithArgs([]string{"nodes", "--columns", "zone,name", "--sort-by=-pods", "--no-headers"}))
+	// output:
+	// NAME    PODS  ZONE
+	// node-a    12  us-east-1a
+	// node-b     7  us-east-1b
+	// node-c   110  us-east-1a
+	//
+	// us-east-1a  node-c
+	// us-east-1a  node-a
+	// us-east-1b  node-b
+}
+
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		args          []string
+		columns       string
+		width         int
+		expected      string
+		expectedError error
+	}
+
+	cases := map[string]testCase{
+		"default": {
+			args: []string{"list"},
+			expected: "ID  DESCRIPTION\n" +
+				" 2  a description that goes on for a while\n" +
+				"10  short\n",
+		},
+		"sort-by-number": {
+			args: []string{"list", "--sort-by", "id"},
+			expected: "ID  DESCRIPTION\n" +
+				" 2  a description that goes on for a while\n" +
+				"10  short\n",
+		},
+		"sort-by-text-descending": {
+			args: []string{"list", "--sort-by", "-description"},
+			expected: "ID  DESCRIPTION\n" +
+				"10  short\n" +
+				" 2  a description that goes on for a while\n",
+		},
+		"sort-descending": {
+			args: []string{"list", "--sort-by", "-ID"},
+			expected: "ID  DESCRIPTION\n" +
+				"10  short\n" +
+				" 2  a description that goes on for a while\n",
+		},
+		"repeated-columns": {
+			args: []string{"list", "--columns", "description", "--columns", "id", "--no-headers"},
+			expected: "a description that goes on for a while   2\n" +
+				"short                                   10\n",
+		},
+		"truncated": {
+			args:  []string{"list"},
+			width: 20,
+			expected: "ID  DESCRIPTION\n" +
+				" 2  a description t…\n" +
+				"10  short\n",
+		},
+		"columns-not-terminal": {
+			args:    []string{"list"},
+			columns: "20",
+			expected: "ID  DESCRIPTION\n" +
+				" 2  a description that goes on for a while\n" +
+				"10  short\n",
+		},
+		"unknown-column": {
+			args:          []string{"list", "--columns", "name"},
+			expectedError: clif.UnknownColumnError("name"),
+		},
+		"unknown-sort-column": {
+			args:          []string{"list", "--sort-by", "-name"},
+			expectedError: clif.UnknownColumnError("name"),
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var output bytes.Buffer
+			var err error
+			app := clif.Application{
+				LookupEnv: func(key string) (string, bool) {
+					if key == "COLUMNS" && testCase.columns != "" {
+						return testCase.columns, true
+					}
+					return "", false
+				},
+				Commands: []clif.Command{
+					{
+						Name: "list",
+						Handler: funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+							if testCase.width > 0 {
+								resp.TableOptions.Width = testCase.width
+							}
+							table := resp.NewTable(clif.Column{Name: "ID", Align: clif.AlignRight}, clif.Column{Name: "DESCRIPTION"})
+							table.AddRow("2", "a description that goes on for a while")
+							table.AddRow("10", "short")
+							err = table.Flush()
+						}),
+					},
+				},
+			}
+			app.Run(context.Background(), clif.WithTableFlags(), clif.WithOutput(&output), clif.WithArgs(testCase.args))
+			if !errors.Is(err, testCase.expectedError) {
+				t.Fatalf("Expected error %v, got %v", testCase.expectedError, err)
+			}
+			if diff := cmp.Diff(testCase.expected, output.String()); diff != "" {
+				t.Errorf("Unexpected output (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWithTableFlagsConflicts(t *testing.T) {
+	t.Parallel()
+	app := clif.Application{
+		Commands: []clif.Command{
+			{
+				Name: "report",
+				Flags: []clif.FlagDef{
+					{Name: "columns", ValueAccepted: true, OnlyAfterCommandName: true, Parser: flagtypes.IntParser{}},
+				},
+				Handler: funcCommandHandler(func(ctx context.Context, resp *clif.Response) {
+					result, _ := clif.RouteResultFromContext(ctx)
+					fmt.Fprintf(resp.Output, "%d columns\n", result.Flags["columns"].(flagtypes.BasicFlag[int64]).Value) //nolint:errcheck,forcetypeassert // if there's an error, we can't do anything, and the parser always returns this type
+				}),
+			},
+			{
+				Name: "list",
+				Handler: funcCommandHandler(func(_ context.Context, resp *clif.Response) {
+					table := resp.NewTable(clif.Column{Name: "ID"}, clif.Column{Name: "NAME"})
+					table.AddRow("1", "api")
+					resp.Err = table.Flush()
+				}),
+			},
+		},
+	}
+	cases := map[string]string{
+		"report --columns 3":              "3 columns\n",
+		"list --columns name":             "NAME\napi\n",
+		"list --no-headers":               "1  api\n",
+		"list --sort-by -id --no-headers": "1  api\n",
+	}
+	for input, expected := range cases {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			var output, errOutput bytes.Buffer
+			code := app.Run(context.Background(), clif.WithTableFlags(), clif.WithOutput(&output), clif.WithError(&errOutput), clif.WithArgs(strings.Fields(input)))
+			if code != 0 {
+				t.Errorf("Expected exit code 0, got %d: %s", code, errOutput.String())
+			}
+			if diff := cmp.Diff(expected, output.String()); diff != "" {
+				t.Errorf("Unexpected diff comparing output (-expected, +got): %s", diff)
+			}
+		})
+	}
+}
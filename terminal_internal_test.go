@@ -0,0 +1,23 @@
+package clif
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestTerminalWidthNotTerminal(t *testing.T) {
+	t.Parallel()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Error creating pipe: %v", err)
+	}
+	defer reader.Close() //nolint:errcheck // if there's an error, we can't do anything
+	defer writer.Close() //nolint:errcheck // if there's an error, we can't do anything
+	if width, ok := terminalWidth(writer); ok {
+		t.Errorf("Expected a pipe not to be a terminal, got width %d", width)
+	}
+	if width, ok := terminalWidth(&bytes.Buffer{}); ok {
+		t.Errorf("Expected a buffer not to be a terminal, got width %d", width)
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package clif
+
+import "io"
+
+// terminalWidth returns the number of columns of the terminal the passed
+// [io.Writer] writes to, and false if it doesn't write to a terminal. Terminal
+// sizes can't be detected on this platform, so it always returns false.
+func terminalWidth(_ io.Writer) (int, bool) {
+	return 0, false
+}
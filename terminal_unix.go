@@ -0,0 +1,32 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package clif
+
+import (
+	"io"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the terminal size reported by the TIOCGWINSZ ioctl.
+type winsize struct {
+	rows   uint16
+	cols   uint16
+	xPixel uint16
+	yPixel uint16
+}
+
+// terminalWidth returns the number of columns of the terminal the passed
+// [io.Writer] writes to, and false if it doesn't write to a terminal.
+func terminalWidth(w io.Writer) (int, bool) {
+	file, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return 0, false
+	}
+	var size winsize
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&size))) //nolint:gosec // the ioctl fills size, which outlives the call
+	if errno != 0 || size.cols == 0 {
+		return 0, false
+	}
+	return int(size.cols), true
+}
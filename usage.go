@@ -0,0 +1,71 @@
+package clif
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UsageError is an error indicating that the input couldn't be routed to a
+// [Command], like an unknown flag, an unexpected argument, or a missing
+// required flag. [Application.Run] passes UsageErrors to the RunOptions'
+// UsageError function to be presented to the user.
+type UsageError struct {
+	// Application is the Application the input was routed against.
+	Application Application
+
+	// CommandPath is the Commands, in order, that were matched before
+	// the error was encountered. Each Command in the slice is the child
+	// of the Command before it in the slice.
+	CommandPath []Command
+
+	// Err is the error that was encountered.
+	Err error
+}
+
+func (err UsageError) Error() string {
+	return err.Err.Error()
+}
+
+func (err UsageError) Unwrap() error {
+	return err.Err
+}
+
+// ExitCode fills the [ExitCoder] interface, and always returns [ExitUsage].
+func (UsageError) ExitCode() int {
+	return ExitUsage
+}
+
+// InvalidCommandError is returned when the input routes to a [Command] that
+// has no Handler, usually because a subcommand was expected. The underlying
+// strings are the input.
+type InvalidCommandError []string
+
+func (err InvalidCommandError) Error() string {
+	return "invalid command: " + strings.Join(err, " ")
+}
+
+// PresentUsageError is the default function for presenting a [UsageError] to
+// the user. It writes the error to the [Response]'s Error, followed by the
+// [Synopsis] of the [Command] the error was encountered in, the output of
+// [SubcommandsHelp] and [FlagsHelp] for it, and the persistent flags it
+// inherits from its parents, and returns [ExitUsage].
+func PresentUsageError(resp *Response, err UsageError) int {
+	var command parseable = err.Application
+	if len(err.CommandPath) > 0 {
+		command = err.CommandPath[len(err.CommandPath)-1]
+	}
+	var builder strings.Builder
+	builder.WriteString(err.Error() + "\n")
+	builder.WriteString("\nUsage: " + Synopsis(err.Application, err.CommandPath) + "\n")
+	if subcommands := SubcommandsHelp(command); subcommands != "" {
+		builder.WriteString("\nCommands:\n" + subcommands)
+	}
+	if flags := FlagsHelp(command); flags != "" {
+		builder.WriteString("\nFlags:\n" + flags)
+	}
+	if flags := flagsHelp(inheritedFlagDefs(err.Application, err.CommandPath)); flags != "" {
+		builder.WriteString("\nGlobal Flags:\n" + flags)
+	}
+	fmt.Fprint(resp.Error, builder.String()) //nolint:errcheck // if there's an error, we can't do anything
+	return err.ExitCode()
+}
@@ -0,0 +1,173 @@
+package clif_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"impractical.co/clif"
+	"impractical.co/clif/flagtypes"
+)
+
+func usageTestApp() clif.Application {
+	return clif.Application{
+		Name: "my-app",
+		Commands: []clif.Command{
+			{
+				Name:        "deploy",
+				Description: "Deploys a service.",
+				Flags: []clif.FlagDef{
+					{Name: "region", Short: 'r', ValueAccepted: true, Parser: flagtypes.StringParser{}, Description: "The region to deploy to."},
+					{Name: "verbose", Parser: flagtypes.BoolParser{}, Description: "Log more information."},
+				},
+				Args:    []clif.ArgDef{{Name: "service"}},
+				Handler: funcCommandHandler(func(_ context.Context, _ *clif.Response) {}),
+			},
+			{
+				Name:        "config",
+				Description: "Manages configuration.",
+				Subcommands: []clif.Command{
+					{Name: "get", Description: "Prints a configuration value.", Handler: funcCommandHandler(func(_ context.Context, _ *clif.Response) {})},
+					{Name: "set", Description: "Changes a configuration value.", Handler: funcCommandHandler(func(_ context.Context, _ *clif.Response) {})},
+				},
+			},
+		},
+	}
+}
+
+func ExamplePresentUsageError() {
+	app := usageTestApp()
+	code := app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"deploy", "--regoin", "us-east-1", "api"}))
+	fmt.Println(code)
+	code = app.Run(context.Background(), clif.WithError(os.Stdout), clif.WithArgs([]string{"config"}))
+	fmt.Println(code)
+	// output:
+	// unexpected flag "regoin", did you mean "--region"?
+	//
+	// Usage: my-app deploy [-r|--region <string>] [--verbose] <service>
+	//
+	// Flags:
+	// region	<string>	The region to deploy to.
+	// verbose	<bool>		Log more information.
+	// 2
+	// invalid command: config
+	//
+	// Usage: my-app config <command>
+	//
+	// Commands:
+	// get	Prints a configuration value.
+	// set	Changes a configuration value.
+	// 2
+}
+
+func TestWithUsageErrors(t *testing.T) {
+	t.Parallel()
+	var presented clif.UsageError
+	var output bytes.Buffer
+	code := usageTestApp().Run(context.Background(), clif.WithError(&output), clif.WithArgs([]string{"config"}), clif.WithUsageErrors(func(_ *clif.Response, err clif.UsageError) int {
+		presented = err
+		return 64
+	}))
+	if code != 64 {
+		t.Errorf("Expected exit code 64, got %d", code)
+	}
+	if output.Len() > 0 {
+		t.Errorf("Expected no output, got %q", output.String())
+	}
+	var invalid clif.InvalidCommandError
+	if !errors.As(presented, &invalid) {
+		t.Errorf("Expected InvalidCommandError, got %+v", presented.Err)
+	}
+	if len(presented.CommandPath) != 1 || presented.CommandPath[0].Name != "config" {
+		t.Errorf("Expected command path to be [config], got %+v", presented.CommandPath)
+	}
+}
+
+func TestRunNonUsageErrors(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		app            func(t *testing.T) clif.Application
+		exitCodes      func(error) int
+		expectedError  string
+		expectedCode   int
+		expectedMapped error
+	}
+
+	cases := map[string]testCase{
+		"invalid-config": {
+			app: func(t *testing.T) clif.Application {
+				t.Helper()
+				path := filepath.Join(t.TempDir(), "config.toml")
+				err := os.WriteFile(path, []byte("[deploy\n"), 0o600)
+				if err != nil {
+					t.Fatalf("Error writing config: %v", err)
+				}
+				app := usageTestApp()
+				app.Config = clif.ConfigFile(path)
+				return app
+			},
+			expectedError:  "invalid configuration on line 1: unterminated table name\n",
+			expectedCode:   clif.ExitFailure,
+			expectedMapped: clif.InvalidConfigError{Line: 1, Reason: "unterminated table name"},
+		},
+		"invalid-default": {
+			app: func(_ *testing.T) clif.Application {
+				app := usageTestApp()
+				app.Flags = []clif.FlagDef{{Name: "retries", ValueAccepted: true, Default: "lots", Parser: flagtypes.IntParser{}}}
+				return app
+			},
+			exitCodes:      func(error) int { return 42 },
+			expectedError:  "invalid default value for flag \"retries\": strconv.ParseInt: parsing \"lots\": invalid syntax\n",
+			expectedCode:   42,
+			expectedMapped: clif.InvalidDefaultError{},
+		},
+		"duplicate-flag": {
+			app: func(_ *testing.T) clif.Application {
+				app := usageTestApp()
+				app.Flags = []clif.FlagDef{{Name: "verbose", Parser: flagtypes.BoolParser{}}}
+				return app
+			},
+			expectedError:  "duplicate definitions of flag \"verbose\"\n",
+			expectedCode:   clif.ExitFailure,
+			expectedMapped: clif.DuplicateFlagNameError("verbose"),
+		},
+	}
+	for name, testCase := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var output bytes.Buffer
+			var mapped error
+			exitCodes := testCase.exitCodes
+			if exitCodes == nil {
+				exitCodes = clif.DefaultExitCode
+			}
+			code := testCase.app(t).Run(context.Background(),
+				clif.WithError(&output),
+				clif.WithArgs([]string{"deploy", "api"}),
+				clif.WithExitCodes(func(err error) int {
+					mapped = err
+					return exitCodes(err)
+				}),
+				clif.WithUsageErrors(func(_ *clif.Response, err clif.UsageError) int {
+					t.Errorf("Unexpected usage error: %v", err)
+					return clif.ExitUsage
+				}),
+			)
+			if code != testCase.expectedCode {
+				t.Errorf("Expected exit code %d, got %d", testCase.expectedCode, code)
+			}
+			if output.String() != testCase.expectedError {
+				t.Errorf("Expected error output %q, got %q", testCase.expectedError, output.String())
+			}
+			if reflect.TypeOf(mapped) != reflect.TypeOf(testCase.expectedMapped) {
+				t.Errorf("Expected ExitCode to be called with %T, got %T", testCase.expectedMapped, mapped)
+			}
+		})
+	}
+}